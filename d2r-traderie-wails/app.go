@@ -4,15 +4,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"github.com/yourusername/d2r-traderie-wails/internal/api"
 	"github.com/yourusername/d2r-traderie-wails/internal/config"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
 	"github.com/yourusername/d2r-traderie-wails/internal/hotkey"
+	"github.com/yourusername/d2r-traderie-wails/internal/listing"
 	"github.com/yourusername/d2r-traderie-wails/internal/mapper"
 	"github.com/yourusername/d2r-traderie-wails/internal/memory"
+	"github.com/yourusername/d2r-traderie-wails/internal/rolls"
+	"github.com/yourusername/d2r-traderie-wails/internal/tooltip"
 	"github.com/yourusername/d2r-traderie-wails/internal/traderie"
 	"github.com/yourusername/d2r-traderie-wails/pkg/models"
 )
@@ -20,26 +27,33 @@ import (
 // App struct
 type App struct {
 	ctx            context.Context
-	memReader      *memory.Reader
+	itemSource     memory.ItemSource
 	traderieClient interface {
 		PostItem(item *models.Item, tItem *traderie.TraderieItem, platform, mode string, ladder bool, region string, prices []models.CurrencyGroupPrice, manualMappings []map[string]interface{}, makeOffer bool, itemList *traderie.TraderieItemList) error
 		TestConnection() error
-		LearnMapping(d2rProp, traderieProp string)
-		MapPropertyName(inGameText, itemClass string) string
 	}
 	hotkeyListener *hotkey.Listener
+	hotkeyErrors   []string // Hotkeys from the config that couldn't be bound
+	missingTables  []string // Excel tables that were neither extracted nor embedded
+	gameDataDir    string   // Folder extracted tables are read from
 	itemList       *traderie.TraderieItemList
 	propertyMapper *mapper.PropertyMapper
+	listings       *listing.History
 	config         *config.Config
 	cookieManager  *api.CookieManager
 	bridge         *api.ExtensionBridge
 	refreshTicker  *time.Ticker
 	refreshStop    chan struct{}
+	emit           func(event string, data ...interface{}) // Sends events to the frontend; replaced to run without a window
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	a := &App{}
+	a.emit = func(event string, data ...interface{}) {
+		runtime.EventsEmit(a.ctx, event, data...)
+	}
+	return a
 }
 
 // startup is called when the app starts
@@ -55,21 +69,43 @@ func (a *App) startup(ctx context.Context) {
 	}
 	a.config = cfg
 
-	// Initialize memory reader
-	log.Println("Looking for Diablo 2 Resurrected process...")
-	memReader, err := memory.NewReader()
-	if err != nil {
-		errMsg := fmt.Sprintf("❌ Could not connect to D2R: %v\n\nPlease run as Administrator!", err)
-		runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
-			Type:    runtime.ErrorDialog,
-			Title:   "D2R Connection Error",
-			Message: errMsg,
-		})
-		log.Println(errMsg)
-		return
+	// Load game tables before any items are parsed
+	a.loadGameData(cfg.Memory.GameDataPath)
+
+	// Initialize item source: recorded snapshots if configured, otherwise live memory
+	if cfg.Memory.ReplayPath != "" {
+		log.Printf("Replaying recorded snapshots from %s...", cfg.Memory.ReplayPath)
+		snapshots, err := memory.NewSnapshotSource(cfg.Memory.ReplayPath)
+		if err != nil {
+			errMsg := fmt.Sprintf("❌ Could not load snapshots: %v", err)
+			runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
+				Type:    runtime.ErrorDialog,
+				Title:   "Snapshot Replay Error",
+				Message: errMsg,
+			})
+			log.Println(errMsg)
+			return
+		}
+		a.itemSource = snapshots
+		log.Println("✅ Using snapshot replay instead of D2R memory")
+	} else {
+		// Supervise the game process: attach when D2R starts, reattach when it restarts
+		recordDir := ""
+		if cfg.Memory.RecordScans {
+			recordDir = filepath.Join(config.DataDir(), "captures")
+		}
+		supervisor := memory.NewSupervisor(memory.NewProcessFinder(recordDir), 2*time.Second,
+			func(pid uint32) {
+				a.emit("game-attached", map[string]interface{}{"pid": pid})
+			},
+			func(pid uint32) {
+				a.emit("game-detached", map[string]interface{}{"pid": pid})
+			},
+		)
+		log.Println("Waiting for Diablo 2 Resurrected process...")
+		supervisor.Start()
+		a.itemSource = supervisor
 	}
-	a.memReader = memReader
-	log.Println("✅ Connected to D2R successfully!")
 
 	// Initialize cookie manager
 	a.cookieManager = api.NewCookieManager()
@@ -85,6 +121,9 @@ func (a *App) startup(ctx context.Context) {
 	// Initialize property mapper
 	a.propertyMapper = mapper.NewPropertyMapper()
 
+	// Remember posted items to catch duplicate listings
+	a.listings = listing.NewHistory(config.DataDir())
+
 	// Initialize Traderie API client with Cloudflare bypass
 	if a.cookieManager.HasSavedCookies() {
 		cookies, err := a.cookieManager.LoadCookies()
@@ -103,15 +142,6 @@ func (a *App) startup(ctx context.Context) {
 		a.traderieClient = api.NewClient(cfg.Traderie.APIKey)
 	}
 
-	// Apply saved mappings to the traderie client
-	if a.traderieClient != nil {
-		mappings := a.propertyMapper.GetAllMappings()
-		for _, m := range mappings {
-			a.traderieClient.LearnMapping(m.D2RProperty, m.TraderieProperty)
-		}
-		log.Printf("✓ Applied %d saved property mappings to Traderie client", len(mappings))
-	}
-
 	// Load traderie item list from embedded data
 	log.Println("Loading traderie item list from embedded data...")
 	itemList, err := traderie.LoadItemListFromEmbedded()
@@ -123,16 +153,7 @@ func (a *App) startup(ctx context.Context) {
 	}
 
 	// Initialize hotkey listener
-	a.hotkeyListener = hotkey.NewListener(cfg.Hotkey, func() {
-		log.Println("Hotkey pressed! Scanning item...")
-		a.handleHotkey()
-	})
-
-	if err := a.hotkeyListener.Start(); err != nil {
-		log.Printf("Failed to start hotkey listener: %v", err)
-	} else {
-		log.Printf("✅ Hotkey listener started. Press %s to capture item.", cfg.Hotkey)
-	}
+	a.startHotkeys(cfg)
 
 	// Start auto-refresh if enabled
 	a.StartAutoRefresh()
@@ -143,18 +164,99 @@ func (a *App) startup(ctx context.Context) {
 	log.Println("===========================================")
 
 	// Emit ready event to frontend
-	runtime.EventsEmit(ctx, "backend-ready", map[string]string{
+	a.emit("backend-ready", map[string]string{
 		"version": "1.4.0-PRICING-FIX",
 	})
 }
 
+// startHotkeys binds the configured hotkeys and starts listening. Hotkeys
+// that can't be bound are logged and reported through GetGameStatus.
+func (a *App) startHotkeys(cfg *config.Config) {
+	listener, captureKey := a.bindHotkeys(cfg)
+	if listener == nil {
+		return
+	}
+
+	if err := listener.Start(); err != nil {
+		log.Printf("Failed to start hotkey listener: %v", err)
+		a.hotkeyErrors = append(a.hotkeyErrors, err.Error())
+		return
+	}
+	a.hotkeyListener = listener
+	log.Printf("✅ Hotkey listener started. Press %s to capture item.", captureKey)
+}
+
+// bindHotkeys binds the capture hotkey and the per-instance ones, skipping
+// the ones that are invalid. An invalid capture hotkey falls back to the
+// default so the per-instance ones still work. It returns the listener and
+// the capture hotkey it bound, or nil when not even the default could be bound.
+func (a *App) bindHotkeys(cfg *config.Config) (*hotkey.Listener, string) {
+	capture := func() {
+		log.Println("Hotkey pressed! Scanning item...")
+		a.handleHotkey("")
+	}
+
+	captureKey := cfg.Hotkey
+	listener, err := hotkey.NewListener(captureKey, capture)
+	if err != nil {
+		captureKey = config.Default().Hotkey
+		log.Printf("⚠️ Invalid capture hotkey: %v; using %s instead", err, captureKey)
+		a.hotkeyErrors = append(a.hotkeyErrors, fmt.Sprintf("Capture hotkey: %v; using %s instead", err, captureKey))
+		if listener, err = hotkey.NewListener(captureKey, capture); err != nil {
+			log.Printf("❌ Failed to bind the default capture hotkey %s: %v", captureKey, err)
+			a.hotkeyErrors = append(a.hotkeyErrors, fmt.Sprintf("Capture hotkey %s: %v", captureKey, err))
+			return nil, ""
+		}
+	}
+
+	// Per-instance hotkeys scan from a specific character's game when multiboxing
+	for key, character := range cfg.Memory.InstanceHotkeys {
+		character := character
+		err := listener.Bind(key, func() {
+			log.Printf("Hotkey pressed! Scanning item from %s...", character)
+			a.handleHotkey(character)
+		})
+		if err != nil {
+			log.Printf("❌ Invalid hotkey for %s: %v", character, err)
+			a.hotkeyErrors = append(a.hotkeyErrors, fmt.Sprintf("Hotkey for %s: %v", character, err))
+		}
+	}
+	return listener, captureKey
+}
+
+// loadGameData loads the excel tables from dir (or the data directory's excel
+// folder), reports the ones that are missing, and uses them to name affixes
+func (a *App) loadGameData(dir string) {
+	if dir == "" {
+		dir = filepath.Join(config.DataDir(), gamedata.OverrideDir)
+	}
+
+	data, err := gamedata.Load(dir)
+	if err != nil {
+		log.Printf("⚠️ Failed to load game data from %s: %v", dir, err)
+		data, _ = gamedata.Load("")
+	}
+	gamedata.SetDefault(data)
+	a.gameDataDir = dir
+
+	// Tables that were neither extracted nor embedded
+	a.missingTables = data.Missing()
+	if len(a.missingTables) > 0 {
+		log.Printf("⚠️ Game data tables not found in %s: %s", dir, strings.Join(a.missingTables, ", "))
+		log.Println("💡 Extract data/global/excel from your D2R install into that folder (see README)")
+	}
+
+	// Affix IDs index the game's affix tables; the embedded copy names them without an extracted one
+	memory.SetAffixNamer(data)
+}
+
 // shutdown is called when the app is shutting down
 func (a *App) shutdown(ctx context.Context) {
 	if a.hotkeyListener != nil {
 		a.hotkeyListener.Stop()
 	}
-	if a.memReader != nil {
-		a.memReader.Close()
+	if a.itemSource != nil {
+		a.itemSource.Close()
 	}
 	log.Println("Application shut down")
 }
@@ -186,9 +288,9 @@ func (a *App) FindTraderieItem(item *models.Item) (*traderie.TraderieItem, bool)
 				element = "Poison"
 			}
 
-			if strings.Contains(pName, "on level-up") {
+			if prop.Skill != nil && prop.Skill.Trigger == "level-up" || strings.Contains(pName, "on level-up") {
 				trigger = "Level-up"
-			} else if strings.Contains(pName, "on death") {
+			} else if prop.Skill != nil && prop.Skill.Trigger == "death" || strings.Contains(pName, "on death") {
 				trigger = "Death"
 			}
 		}
@@ -203,6 +305,17 @@ func (a *App) FindTraderieItem(item *models.Item) (*traderie.TraderieItem, bool)
 		}
 	}
 
+	// Runewords list under the runeword's own entry, never the base item
+	if item.Runeword != "" {
+		tItem, found := a.itemList.FindItemByName(item.Runeword)
+		if found {
+			log.Printf("✅ Found matching Traderie runeword: %s (ID: %s)", tItem.Name, tItem.ID)
+			return tItem, true
+		}
+		log.Printf("❌ Runeword %s is not in the Traderie item list", item.Runeword)
+		return nil, false
+	}
+
 	// 1. Try exact name match
 	tItem, found := a.itemList.FindItemByName(itemName)
 	if found {
@@ -210,6 +323,15 @@ func (a *App) FindTraderieItem(item *models.Item) (*traderie.TraderieItem, bool)
 		return tItem, true
 	}
 
+	// Magic and rare items are named after their affixes; list them under the base item
+	if item.BaseName != "" && item.BaseName != itemName {
+		tItem, found = a.itemList.FindItemByName(item.BaseName)
+		if found {
+			log.Printf("✅ Found matching Traderie item by base name: %s (ID: %s)", tItem.Name, tItem.ID)
+			return tItem, true
+		}
+	}
+
 	// 2. Try exact type match
 	if itemType != "" {
 		log.Printf("⚠ Item '%s' not found, trying fallback to type '%s'", itemName, itemType)
@@ -240,23 +362,62 @@ func (a *App) FindTraderieItem(item *models.Item) (*traderie.TraderieItem, bool)
 	return nil, false
 }
 
-// handleHotkey is called when the hotkey is pressed
-func (a *App) handleHotkey() {
-	// Read item from memory
-	item, err := a.memReader.GetHoveredItem()
+// handleHotkey is called when the hotkey is pressed. When character is set,
+// the item is read from the game that character is logged into.
+func (a *App) handleHotkey(character string) {
+	if a.itemSource == nil {
+		a.emit("item-scan-error", "Not connected to D2R")
+		return
+	}
+
+	// Read item from the active item source
+	var item *models.Item
+	var err error
+	if supervisor, ok := a.itemSource.(*memory.Supervisor); ok && character != "" {
+		item, err = supervisor.GetHoveredItemFor(character)
+	} else {
+		item, err = a.itemSource.GetHoveredItem()
+	}
 	if err != nil {
 		log.Printf("Failed to read item: %v", err)
-		runtime.EventsEmit(a.ctx, "item-scan-error", err.Error())
+		a.emit("item-scan-error", err.Error())
 		return
 	}
 
 	if item == nil {
 		log.Println("No item currently hovered or on cursor")
-		runtime.EventsEmit(a.ctx, "item-scan-error", "No item hovered")
+		a.emit("item-scan-error", "No item hovered")
 		return
 	}
 
+	a.showItem(item)
+}
+
+// ImportItemText parses an item tooltip pasted as text and shows it like a
+// scanned item, for price checks and listings without reading game memory
+func (a *App) ImportItemText(text string) (*models.Item, error) {
+	item, err := memory.ParseItemText(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse item text: %w", err)
+	}
+
+	log.Printf("✓ Imported item from text: %s (%s)", item.Name, item.Quality)
+	a.showItem(item)
+	return item, nil
+}
+
+// showItem maps a captured item's properties to Traderie and sends it to the frontend
+func (a *App) showItem(item *models.Item) {
 	log.Printf("✓ Captured item: %s (%s) Type: %s", item.Name, item.Quality, item.Type)
+	if item.Location != nil {
+		log.Printf("✓ Item location: %s", item.Location.Type)
+	}
+	if item.Instance != nil {
+		log.Printf("✓ Item instance: PID %d (%s)", item.Instance.PID, item.Instance.Character)
+	}
+	if item.Character != nil {
+		log.Printf("✓ Item character: %s (level %d %s)", item.Character.Name, item.Character.Level, item.Character.Class)
+	}
 
 	// Find matching traderie item
 	traderieItem, found := a.FindTraderieItem(item)
@@ -274,7 +435,19 @@ func (a *App) handleHotkey() {
 	}
 
 	// Prepare initial mappings based on saved preferences
-	itemMappings := []map[string]string{}
+	itemMappings := []map[string]interface{}{}
+
+	// Class-specific skill mappings follow the character holding the item
+	itemClass := ""
+	if item.Character != nil {
+		itemClass = strings.ToLower(item.Character.Class)
+	}
+
+	// Mappings can be learned for the Traderie item itself
+	traderieName := ""
+	if traderieItem != nil {
+		traderieName = traderieItem.Name
+	}
 
 	if traderieItem != nil {
 		for _, prop := range traderieItem.Properties {
@@ -315,9 +488,12 @@ func (a *App) handleHotkey() {
 						traderieRarity = "rare" // Fallback for crafted if not explicitly listed
 					}
 
-					itemMappings = append(itemMappings, map[string]string{
+					itemMappings = append(itemMappings, map[string]interface{}{
 						"d2rProp":      fmt.Sprintf("Quality: %s", item.Quality),
 						"traderieProp": "Rarity",
+						"rule":         mapper.RuleBuiltin,
+						"detail":       "item quality",
+						"scope":        mapper.RuleGlobal,
 					})
 					
 					// We should also store the actual mapped value somewhere so the frontend can pre-select it.
@@ -329,42 +505,176 @@ func (a *App) handleHotkey() {
 		}
 	}
 
-	for _, prop := range item.Properties {
+	// Base stats (defense, damage, requirements, item level) are mapped like any other property
+	baseProps := baseProperties(item)
+
+	for _, prop := range append(baseProps, item.Properties...) {
 		d2rPropStr := fmt.Sprintf("%s: %v", prop.Name, prop.Value)
 		
-		// 1. Try persistent learned mappings first
-		mapping, found := a.propertyMapper.GetMapping(prop.Name, "")
-		if found {
-			itemMappings = append(itemMappings, map[string]string{
-				"d2rProp":      d2rPropStr,
-				"traderieProp": mapping,
-			})
-		} else {
-			// 2. Fallback to automatic matching logic
-			autoMapping := a.traderieClient.MapPropertyName(prop.Name, "")
-			if autoMapping != "" {
-				itemMappings = append(itemMappings, map[string]string{
-					"d2rProp":      d2rPropStr,
-					"traderieProp": autoMapping,
-				})
-			} else {
-				// 3. Just add the D2R property with empty Traderie mapping so user can select
-				itemMappings = append(itemMappings, map[string]string{
-					"d2rProp":      d2rPropStr,
-					"traderieProp": "",
-				})
-			}
-		}
+		// Learned mappings win over built-in ones; the resolution says which rule matched
+		res := a.propertyMapper.Resolve(prop, mapper.Context{ItemName: traderieName, Class: itemClass, Candidates: traderieProperties})
+		itemMappings = append(itemMappings, map[string]interface{}{
+			"d2rProp":      d2rPropStr,
+			"traderieProp": res.Traderie,
+			"rule":         res.Rule,
+			"detail":       res.Detail,
+			"confidence":   res.Confidence,
+			"alternatives": res.Alternatives,
+			"scope":        scopeKind(res.Rule),
+		})
+	}
+
+	// Flag stats the tooltip can't describe; they usually mean the game data is out of date
+	for _, problem := range tooltip.Check(item) {
+		log.Printf("⚠️ Tooltip check: %s", problem)
 	}
 
+	// Check tradeability with the options the item will be listed with by default
+	defaults := listing.Derive(item, a.configuredOptions())
+
 	// Send item to frontend
-	runtime.EventsEmit(a.ctx, "item-scanned", map[string]interface{}{
+	a.emit("item-scanned", map[string]interface{}{
 		"item":               item,
 		"traderieProperties": traderieProperties,
 		"mappings":           itemMappings,
+		"mappingScopes":      mapper.Scope{ItemName: traderieName, Class: itemClass},
+		"baseProperties":     baseProps,
+		"perfection":         rolls.Score(item),
+		"tooltip":            tooltip.Render(item),
+		"tradingDefaults":    defaults,
+		"issues":             listing.Validate(item, defaults, defaults.Options),
+		"duplicate":          a.activeListing(item),
 	})
 }
 
+// baseProperties turns an item's base stats into properties so they can be mapped to Traderie
+func baseProperties(item *models.Item) []models.Property {
+	var props []models.Property
+
+	if item.Defense > 0 {
+		props = append(props, models.Property{Name: "Defense", Value: item.Defense})
+	}
+	if item.Damage != nil {
+		props = append(props, models.Property{Name: "Damage", Value: fmt.Sprintf("%d-%d", item.Damage.Min, item.Damage.Max)})
+	}
+	if item.ItemLevel != nil {
+		props = append(props, models.Property{Name: "Item Level", Value: *item.ItemLevel})
+	}
+	if item.Requirements != nil {
+		if item.Requirements.Level > 0 {
+			props = append(props, models.Property{Name: "Required Level", Value: item.Requirements.Level})
+		}
+		if item.Requirements.Strength > 0 {
+			props = append(props, models.Property{Name: "Required Strength", Value: item.Requirements.Strength})
+		}
+		if item.Requirements.Dexterity > 0 {
+			props = append(props, models.Property{Name: "Required Dexterity", Value: item.Requirements.Dexterity})
+		}
+	}
+
+	return props
+}
+
+// GetGameStatus reports whether D2R (or a snapshot replay) is attached
+func (a *App) GetGameStatus() map[string]interface{} {
+	status := map[string]interface{}{
+		"attached":        false,
+		"replay":          false,
+		"pid":             0,
+		"hotkeyErrors":    a.hotkeyErrors,
+		"missingGameData": a.missingTables,
+		"gameDataDir":     a.gameDataDir,
+	}
+
+	switch source := a.itemSource.(type) {
+	case *memory.Supervisor:
+		if instances := source.Instances(); len(instances) > 0 {
+			status["attached"] = true
+			status["pid"] = source.Selected()
+		}
+	case *memory.SnapshotSource:
+		status["attached"] = true
+		status["replay"] = true
+	}
+
+	return status
+}
+
+// ListGameInstances returns every attached D2R process with its logged-in character
+func (a *App) ListGameInstances() []models.GameInstance {
+	supervisor, ok := a.itemSource.(*memory.Supervisor)
+	if !ok {
+		return []models.GameInstance{}
+	}
+	return supervisor.Instances()
+}
+
+// SelectGameInstance picks which D2R process scans read from this session (0 = automatic)
+func (a *App) SelectGameInstance(pid int) error {
+	supervisor, ok := a.itemSource.(*memory.Supervisor)
+	if !ok {
+		return fmt.Errorf("not reading from live D2R processes")
+	}
+
+	if err := supervisor.Select(uint32(pid)); err != nil {
+		return err
+	}
+	log.Printf("✓ Selected D2R instance: %d", pid)
+	return nil
+}
+
+// ScanAllItems reads every item in the stash, shared stash tabs, inventory,
+// Horadric Cube, equipment and mercenary equipment, each tagged with its location
+func (a *App) ScanAllItems() ([]*models.Item, error) {
+	if a.itemSource == nil {
+		return nil, fmt.Errorf("not connected to D2R")
+	}
+
+	items, err := a.itemSource.ScanAllItems()
+	if err != nil {
+		log.Printf("Failed to scan items: %v", err)
+		return nil, err
+	}
+
+	log.Printf("✓ Full scan returned %d items", len(items))
+	return items, nil
+}
+
+// OpenSaveFile asks for a .d2s character or .d2i shared stash file and
+// returns its items, so offline mules can be listed without launching the game
+func (a *App) OpenSaveFile() (*memory.SaveFile, error) {
+	options := runtime.OpenDialogOptions{
+		Title: "Open D2R Save File",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "D2R Saves (*.d2s;*.d2i)", Pattern: "*.d2s;*.d2i"},
+		},
+	}
+	if saves := memory.SavesDir(); saves != "" {
+		if _, err := os.Stat(saves); err == nil {
+			options.DefaultDirectory = saves
+		}
+	}
+
+	path, err := runtime.OpenFileDialog(a.ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open save file: %w", err)
+	}
+	if path == "" {
+		return nil, nil // Dialog cancelled
+	}
+
+	return memory.LoadSaveFile(path)
+}
+
+// ShowItem shows an item picked from a loaded save file like a scanned item
+func (a *App) ShowItem(item *models.Item) error {
+	if item == nil {
+		return fmt.Errorf("no item to show")
+	}
+	a.showItem(item)
+	return nil
+}
+
 // GetAllItems returns all items from the Traderie list for autocomplete
 func (a *App) GetAllItems() []string {
 	if a.itemList == nil {
@@ -380,6 +690,54 @@ func (a *App) GetAllItems() []string {
 	return items
 }
 
+// GetItemTooltip returns the item's tooltip lines as the game shows them
+func (a *App) GetItemTooltip(item *models.Item) []string {
+	return tooltip.Render(item)
+}
+
+// CopyItemTooltip copies the item's tooltip text to the clipboard, e.g. for a trade post
+func (a *App) CopyItemTooltip(item *models.Item) error {
+	if item == nil {
+		return fmt.Errorf("no item to copy")
+	}
+	if err := runtime.ClipboardSetText(a.ctx, tooltip.Text(item)); err != nil {
+		return fmt.Errorf("failed to copy tooltip: %w", err)
+	}
+	log.Printf("✓ Copied tooltip of %s to clipboard", item.Name)
+	return nil
+}
+
+// ValidateListing checks whether an item can be listed with the given trading
+// options and returns the reasons it may not be tradeable
+func (a *App) ValidateListing(item *models.Item, opts map[string]interface{}) []listing.Issue {
+	defaults := listing.Derive(item, a.configuredOptions())
+	return listing.Validate(item, defaults, chosenOptions(opts, defaults.Options))
+}
+
+// configuredOptions returns the trading options saved in the config
+func (a *App) configuredOptions() listing.Options {
+	return listing.Options{
+		Mode:   a.config.Traderie.Mode,
+		Ladder: a.config.Traderie.Ladder,
+		Region: a.config.Traderie.Region,
+	}
+}
+
+// chosenOptions reads the mode, ladder and region picked in the UI, keeping defaults for any not given
+func chosenOptions(opts map[string]interface{}, defaults listing.Options) listing.Options {
+	chosen := defaults
+	if val, ok := opts["mode"].(string); ok && val != "" {
+		chosen.Mode = val
+	}
+	if val, ok := opts["ladder"].(bool); ok {
+		chosen.Ladder = val
+	}
+	if val, ok := opts["region"].(string); ok && val != "" {
+		chosen.Region = val
+	}
+	return chosen
+}
+
 // GetTradingOptions returns the saved trading options
 func (a *App) GetTradingOptions() map[string]interface{} {
 	return map[string]interface{}{
@@ -398,29 +756,26 @@ func (a *App) PostItem(item *models.Item, platform string, tradingOpts map[strin
 	log.Println("Posting item to Traderie...")
 	log.Printf("Item: %s", item.Name)
 
-	// Learn any mappings provided from the UI
-	if mappings, ok := tradingOpts["mappings"].([]interface{}); ok {
-		for _, m := range mappings {
-			if mapping, ok := m.(map[string]interface{}); ok {
-				d2rProp, _ := mapping["d2rProp"].(string)
-				traderieProp, _ := mapping["traderieProp"].(string)
-				
-				if d2rProp != "" && traderieProp != "" {
-					// Clean up the name (e.g. "to Life: 50" -> "to Life")
-					cleanD2R := d2rProp
-					if idx := strings.Index(d2rProp, ":"); idx != -1 {
-						cleanD2R = strings.TrimSpace(d2rProp[:idx])
-					}
-					
-					// Learn in the active client
-					a.traderieClient.LearnMapping(cleanD2R, traderieProp)
-					// Also save to the persistent mapper
-					a.propertyMapper.LearnMapping(cleanD2R, traderieProp, "")
-				}
-			}
+	// Refuse untradeable items unless the user overrode the checks
+	issues := a.ValidateListing(item, tradingOpts)
+	for _, issue := range issues {
+		log.Printf("⚠️ Tradeability check (%s): %s", issue.Code, issue.Reason)
+	}
+	if blocking := listing.Blocking(issues); len(blocking) > 0 {
+		if override, _ := tradingOpts["overrideChecks"].(bool); !override {
+			return fmt.Errorf("item can't be listed: %s", listing.Reasons(blocking))
 		}
-		// Save mappings to disk
-		_ = a.propertyMapper.Save()
+		log.Println("⚠️ Posting despite failed tradeability checks (overridden)")
+	}
+
+	// Refuse to list the same item twice unless the user chose to list it again
+	fingerprint := listing.Fingerprint(item)
+	if posted := a.activeListing(item); posted != nil {
+		if listAgain, _ := tradingOpts["listAgain"].(bool); !listAgain {
+			return fmt.Errorf("%s was already listed on %s; choose \"List again\" to post it again",
+				item.Name, posted.PostedAt.Format("Jan 2 15:04"))
+		}
+		log.Printf("⚠️ Listing %s again (fingerprint %s)", item.Name, fingerprint)
 	}
 
 	// Find the Traderie Item ID
@@ -429,6 +784,21 @@ func (a *App) PostItem(item *models.Item, platform string, tradingOpts map[strin
 		return fmt.Errorf("could not find item '%s' (or type '%s') in Traderie database. Please ensure the name matches Traderie's exactly.", item.Name, item.Type)
 	}
 
+	// Learn any mappings provided from the UI, in the scope chosen for each
+	if mappings, ok := tradingOpts["mappings"].([]interface{}); ok {
+		scopes := mapper.Scope{ItemName: tItem.Name}
+		if item.Character != nil {
+			scopes.Class = strings.ToLower(item.Character.Class)
+		}
+		for _, m := range mappings {
+			if mapping, ok := m.(map[string]interface{}); ok {
+				a.learnMapping(mapping, scopes)
+			}
+		}
+		// Save mappings to disk
+		_ = a.propertyMapper.Save()
+	}
+
 	// Extract offer items from pricing options
 	currencyGroupPrices := []models.CurrencyGroupPrice{}
 	if offers, ok := pricingOpts["offers"].([]interface{}); ok {
@@ -488,18 +858,11 @@ func (a *App) PostItem(item *models.Item, platform string, tradingOpts map[strin
 	if p == "" {
 		p = a.config.Traderie.Platform
 	}
-	m := "softcore"
-	if val, ok := tradingOpts["mode"].(string); ok {
-		m = val
-	}
-	l := true
-	if val, ok := tradingOpts["ladder"].(bool); ok {
-		l = val
-	}
-	r := "Americas"
-	if val, ok := tradingOpts["region"].(string); ok {
-		r = val
-	}
+
+	// Mode and ladder default to the character holding the item
+	defaults := listing.Derive(item, a.configuredOptions())
+	chosen := chosenOptions(tradingOpts, defaults.Options)
+	m, l, r := chosen.Mode, chosen.Ladder, chosen.Region
 
 	// Extract pricing options
 	makeOffer := true
@@ -540,10 +903,28 @@ func (a *App) PostItem(item *models.Item, platform string, tradingOpts map[strin
 	}
 
 	log.Println("✅ Item posted successfully!")
+
+	if a.listings != nil {
+		posting := listing.Posting{Fingerprint: fingerprint, Item: item.Name, Platform: p, Mode: m, Ladder: l, PostedAt: time.Now()}
+		if err := a.listings.Record(posting); err != nil {
+			log.Printf("⚠️ Failed to record listing: %v", err)
+		}
+	}
+	return nil
+}
+
+// activeListing returns the active listing already posted for the item, or nil
+func (a *App) activeListing(item *models.Item) *listing.Posting {
+	if a.listings == nil {
+		return nil
+	}
+	if posted, ok := a.listings.Active(listing.Fingerprint(item)); ok {
+		return &posted
+	}
 	return nil
 }
 
-// GetPropertyMapping returns a saved mapping for a D2R property
+// GetPropertyMapping returns the Traderie property a D2R property maps to, learned or built-in
 func (a *App) GetPropertyMapping(d2rProp string) string {
 	// Clean up the name (e.g. "Max Life: 50" -> "Max Life")
 	cleanD2R := d2rProp
@@ -551,32 +932,82 @@ func (a *App) GetPropertyMapping(d2rProp string) string {
 		cleanD2R = strings.TrimSpace(d2rProp[:idx])
 	}
 
-	mapping, _ := a.propertyMapper.GetMapping(cleanD2R, "")
-	return mapping
+	return a.propertyMapper.Resolve(models.Property{Name: cleanD2R}, mapper.Context{}).Traderie
 }
 
-// SavePropertyMappings saves the current manual mappings to the persistent store
-func (a *App) SavePropertyMappings(mappings []map[string]interface{}) error {
+// SavePropertyMappings saves the current manual mappings to the persistent store.
+// Each mapping's "scope" ("global", "item" or "class") decides whether it's
+// learned for every item, for the Traderie item matching item, or for its character's class.
+func (a *App) SavePropertyMappings(item *models.Item, mappings []map[string]interface{}) error {
 	log.Println("Saving manual property mappings...")
+	scopes := a.mappingScopes(item)
 	for _, mapping := range mappings {
-		d2rProp, _ := mapping["d2rProp"].(string)
-		traderieProp, _ := mapping["traderieProp"].(string)
-		if d2rProp != "" && traderieProp != "" {
-			// Clean up the name (e.g. "to Life: 50" -> "to Life")
-			cleanD2R := d2rProp
-			if idx := strings.Index(d2rProp, ":"); idx != -1 {
-				cleanD2R = strings.TrimSpace(d2rProp[:idx])
-			}
-			
-			// Learn in the active client
-			a.traderieClient.LearnMapping(cleanD2R, traderieProp)
-			// Also save to the persistent mapper
-			a.propertyMapper.LearnMapping(cleanD2R, traderieProp, "")
-		}
+		a.learnMapping(mapping, scopes)
 	}
 	return a.propertyMapper.Save()
 }
 
+// mappingScopes returns the item and class mappings for item can be learned for
+func (a *App) mappingScopes(item *models.Item) mapper.Scope {
+	var scopes mapper.Scope
+	if item == nil {
+		return scopes
+	}
+	if tItem, found := a.FindTraderieItem(item); found {
+		scopes.ItemName = tItem.Name
+	}
+	if item.Character != nil {
+		scopes.Class = strings.ToLower(item.Character.Class)
+	}
+	return scopes
+}
+
+// learnMapping learns one mapping from the UI in the scope it chose. Item and
+// class scopes that aren't available fall back to global.
+func (a *App) learnMapping(mapping map[string]interface{}, scopes mapper.Scope) {
+	d2rProp, _ := mapping["d2rProp"].(string)
+	traderieProp, _ := mapping["traderieProp"].(string)
+	if d2rProp == "" || traderieProp == "" {
+		return
+	}
+
+	// Only rows the user picked or confirmed are learned; the UI marks them
+	// with a learned rule. Built-in and fuzzy guesses stay guesses.
+	switch rule, _ := mapping["rule"].(string); rule {
+	case mapper.RuleItem, mapper.RuleClass, mapper.RuleGlobal:
+	default:
+		return
+	}
+
+	// Clean up the name (e.g. "to Life: 50" -> "to Life")
+	cleanD2R := d2rProp
+	if idx := strings.Index(d2rProp, ":"); idx != -1 {
+		cleanD2R = strings.TrimSpace(d2rProp[:idx])
+	}
+
+	kind, _ := mapping["scope"].(string)
+	scope := mapper.Scope{}
+	switch kind {
+	case mapper.RuleItem:
+		scope.ItemName = scopes.ItemName
+	case mapper.RuleClass:
+		scope.Class = scopes.Class
+	}
+	if kind != "" && kind != mapper.RuleGlobal && scope == (mapper.Scope{}) {
+		log.Printf("⚠️ No %s to scope '%s' to; learning it for all items", kind, cleanD2R)
+	}
+	a.propertyMapper.LearnMapping(cleanD2R, traderieProp, scope)
+}
+
+// scopeKind is the scope the UI preselects for a resolved mapping: the scope
+// it was learned in, or global for mappings that weren't learned
+func scopeKind(rule string) string {
+	if rule == mapper.RuleItem || rule == mapper.RuleClass {
+		return rule
+	}
+	return mapper.RuleGlobal
+}
+
 // SetAuthToken updates the Traderie auth token/API key
 func (a *App) SetAuthToken(token string) error {
 	log.Println("Updating Traderie auth token...")
@@ -697,22 +1128,11 @@ func (a *App) GenerateSearchURL(item *models.Item, searchRange int, propertyMapp
 		platform = "PC"
 	}
 
-	mode := a.config.Traderie.Mode
-	if v, ok := opts["mode"].(string); ok && v != "" {
-		mode = v
-	}
-
-	ladder := "true"
-	if v, ok := opts["ladder"].(bool); ok {
-		if !v {
-			ladder = "false"
-		}
-	} else {
-		// Use config if not in opts
-		if !a.config.Traderie.Ladder {
-			ladder = "false"
-		}
-	}
+	// Mode and ladder default to the character holding the item, like a post
+	defaults := listing.Derive(item, a.configuredOptions())
+	chosen := chosenOptions(opts, defaults.Options)
+	mode := chosen.Mode
+	ladder := strconv.FormatBool(chosen.Ladder)
 
 	// Region. Default to all if not specific
 	region := ""
@@ -931,7 +1351,7 @@ func (a *App) RefreshListings() error {
 	}
 
 	log.Println("✅ Listings refreshed successfully!")
-	runtime.EventsEmit(a.ctx, "listings-refreshed", true)
+	a.emit("listings-refreshed", true)
 	return nil
 }
 
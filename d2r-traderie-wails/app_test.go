@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/yourusername/d2r-traderie-wails/internal/config"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/internal/listing"
+	"github.com/yourusername/d2r-traderie-wails/internal/mapper"
+	"github.com/yourusername/d2r-traderie-wails/internal/memory"
+	"github.com/yourusername/d2r-traderie-wails/internal/traderie"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// event is one event the app sent to the frontend
+type event struct {
+	name string
+	data []interface{}
+}
+
+// post is one listing sent to the fake Traderie client
+type post struct {
+	item             string
+	mode, region     string
+	ladder, askOffer bool
+}
+
+// fakeTraderie records posts instead of calling Traderie
+type fakeTraderie struct {
+	posts []post
+}
+
+func (f *fakeTraderie) PostItem(item *models.Item, tItem *traderie.TraderieItem, platform, mode string, ladder bool, region string, prices []models.CurrencyGroupPrice, manualMappings []map[string]interface{}, makeOffer bool, itemList *traderie.TraderieItemList) error {
+	f.posts = append(f.posts, post{item: tItem.Name, mode: mode, region: region, ladder: ladder, askOffer: makeOffer})
+	return nil
+}
+
+func (f *fakeTraderie) TestConnection() error {
+	return nil
+}
+
+// headlessApp builds an app that replays the given items and records its
+// events, without a window or a running game
+func headlessApp(t *testing.T, d2items ...data.Item) (*App, *[]event, *fakeTraderie) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+
+	dir := t.TempDir()
+	for i, d2item := range d2items {
+		raw, err := json.Marshal(d2item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%d.json", i)), raw, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	source, err := memory.NewSnapshotSource(dir)
+	if err != nil {
+		t.Fatalf("NewSnapshotSource: %v", err)
+	}
+
+	events := &[]event{}
+	client := &fakeTraderie{}
+
+	a := NewApp()
+	a.emit = func(name string, data ...interface{}) {
+		*events = append(*events, event{name: name, data: data})
+	}
+	a.config = config.Default()
+	a.loadGameData(t.TempDir())
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+	a.itemSource = source
+	a.traderieClient = client
+	a.propertyMapper = mapper.NewPropertyMapper()
+	a.listings = listing.NewHistory(t.TempDir())
+	a.itemList = &traderie.TraderieItemList{Items: []traderie.TraderieItem{{ID: "1", Name: "Shako"}}}
+	return a, events, client
+}
+
+var stashedShako = data.Item{UnitID: 1, Name: "Shako", Quality: item.QualityUnique, Identified: true,
+	Location: item.Location{LocationType: item.LocationStash}}
+
+func TestHandleHotkeyReplaysSnapshot(t *testing.T) {
+	a, events, _ := headlessApp(t, stashedShako)
+
+	a.handleHotkey("")
+
+	if len(*events) != 1 || (*events)[0].name != "item-scanned" {
+		t.Fatalf("events = %+v, want one item-scanned", *events)
+	}
+	payload := (*events)[0].data[0].(map[string]interface{})
+	scanned := payload["item"].(*models.Item)
+	if scanned.Name != "Shako" || scanned.Location.Type != "stash" {
+		t.Errorf("scanned %s in %s, want the Shako in the stash", scanned.Name, scanned.Location.Type)
+	}
+}
+
+func TestHandleHotkeyErrors(t *testing.T) {
+	a, events, _ := headlessApp(t, stashedShako)
+	a.itemSource = nil
+
+	a.handleHotkey("")
+
+	want := []event{{name: "item-scan-error", data: []interface{}{"Not connected to D2R"}}}
+	if !reflect.DeepEqual(*events, want) {
+		t.Errorf("events = %+v, want %+v", *events, want)
+	}
+}
+
+func TestPostItem(t *testing.T) {
+	a, _, client := headlessApp(t, stashedShako)
+	item, err := a.itemSource.GetHoveredItem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.PostItem(item, "", map[string]interface{}{"region": "Europe"}, map[string]interface{}{}); err != nil {
+		t.Fatalf("PostItem: %v", err)
+	}
+	want := []post{{item: "Shako", mode: "softcore", region: "Europe", ladder: true, askOffer: true}}
+	if !reflect.DeepEqual(client.posts, want) {
+		t.Errorf("posts = %+v, want %+v", client.posts, want)
+	}
+
+	// The same item again is a duplicate unless the user lists it again
+	if err := a.PostItem(item, "", map[string]interface{}{}, map[string]interface{}{}); err == nil {
+		t.Error("PostItem() of an already listed item succeeded")
+	}
+	if err := a.PostItem(item, "", map[string]interface{}{"listAgain": true}, map[string]interface{}{}); err != nil {
+		t.Errorf("PostItem() with listAgain: %v", err)
+	}
+	if len(client.posts) != 2 {
+		t.Errorf("posted %d times, want 2", len(client.posts))
+	}
+}
+
+func TestCharacterFlagsOverrideConfiguredLadder(t *testing.T) {
+	a, _, client := headlessApp(t, stashedShako)
+	item, err := a.itemSource.GetHoveredItem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The config lists on ladder, but the online character holding the item isn't a ladder one
+	item.Character = &models.Character{Name: "Tradebot", Expansion: true, FlagsKnown: true}
+
+	if err := a.PostItem(item, "", map[string]interface{}{}, map[string]interface{}{}); err != nil {
+		t.Fatalf("PostItem: %v", err)
+	}
+	if len(client.posts) != 1 || client.posts[0].ladder {
+		t.Errorf("posts = %+v, want one non-ladder post", client.posts)
+	}
+
+	url, err := a.GenerateSearchURL(item, 10, nil, nil, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("GenerateSearchURL: %v", err)
+	}
+	if !strings.Contains(url, "prop_Ladder=false") {
+		t.Errorf("GenerateSearchURL() = %s, want a non-ladder search", url)
+	}
+
+	// An explicit choice in the UI still wins
+	url, err = a.GenerateSearchURL(item, 10, nil, nil, map[string]interface{}{"ladder": true})
+	if err != nil {
+		t.Fatalf("GenerateSearchURL: %v", err)
+	}
+	if !strings.Contains(url, "prop_Ladder=true") {
+		t.Errorf("GenerateSearchURL() with ladder chosen = %s, want a ladder search", url)
+	}
+}
+
+func TestSavePropertyMappingsLearnsOnlyUserRows(t *testing.T) {
+	a, _, _ := headlessApp(t, stashedShako)
+	item, err := a.itemSource.GetHoveredItem()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []map[string]interface{}{
+		{"d2rProp": "Magic Find: 50", "traderieProp": "Magic Find", "rule": mapper.RuleBuiltin, "scope": mapper.RuleGlobal},
+		{"d2rProp": "to Mana: 2", "traderieProp": "Mana", "rule": mapper.RuleFuzzy, "scope": mapper.RuleGlobal},
+		{"d2rProp": "Unknown Stat: 1", "traderieProp": "", "rule": mapper.RuleNone, "scope": mapper.RuleGlobal},
+		{"d2rProp": "All Skills: 2", "traderieProp": "Skills", "rule": mapper.RuleItem, "scope": mapper.RuleItem},
+	}
+	if err := a.SavePropertyMappings(item, rows); err != nil {
+		t.Fatalf("SavePropertyMappings: %v", err)
+	}
+
+	want := []mapper.PropertyMapping{{D2RProperty: "All Skills", TraderieProperty: "Skills", ItemName: "Shako"}}
+	if got := a.propertyMapper.GetAllMappings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("learned %+v, want only the row the user set %+v", got, want)
+	}
+}
+
+func TestBindHotkeysFallsBackFromInvalidCaptureKey(t *testing.T) {
+	a, _, _ := headlessApp(t, stashedShako)
+	cfg := config.Default()
+	cfg.Hotkey = "F13"
+	cfg.Memory.InstanceHotkeys = map[string]string{"F9": "Mulehaven", "F10": "Tradebot"}
+
+	listener, captureKey := a.bindHotkeys(cfg)
+
+	if listener == nil || captureKey != "F9" {
+		t.Fatalf("bindHotkeys() = %v, %q, want a listener on F9", listener, captureKey)
+	}
+	// F10 bound; F9 is taken by the fallback capture hotkey
+	want := []string{
+		`Capture hotkey: unknown hotkey "F13", expected F1-F12; using F9 instead`,
+		"Hotkey for Mulehaven: hotkey F9 is already bound to item capture",
+	}
+	if !reflect.DeepEqual(a.hotkeyErrors, want) {
+		t.Errorf("hotkeyErrors = %q, want %q", a.hotkeyErrors, want)
+	}
+}
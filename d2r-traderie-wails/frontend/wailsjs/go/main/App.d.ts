@@ -1,8 +1,12 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {listing} from '../models';
+import {memory} from '../models';
 import {models} from '../models';
 import {traderie} from '../models';
 
+export function CopyItemTooltip(arg1:models.Item):Promise<void>;
+
 export function FindTraderieItem(arg1:models.Item):Promise<traderie.TraderieItem|boolean>;
 
 export function GenerateSearchURL(arg1:models.Item,arg2:number,arg3:Array<Record<string, string>>,arg4:Array<string>,arg5:Record<string, any>):Promise<string>;
@@ -13,28 +17,46 @@ export function GetAuthToken():Promise<string>;
 
 export function GetCookieSetupInstructions():Promise<string>;
 
+export function GetGameStatus():Promise<Record<string, any>>;
+
+export function GetItemTooltip(arg1:models.Item):Promise<Array<string>>;
+
 export function GetPropertyMapping(arg1:string):Promise<string>;
 
 export function GetTradingOptions():Promise<Record<string, any>>;
 
 export function HasSavedCookies():Promise<boolean>;
 
+export function ImportItemText(arg1:string):Promise<models.Item>;
+
+export function ListGameInstances():Promise<Array<models.GameInstance>>;
+
+export function OpenSaveFile():Promise<memory.SaveFile>;
+
 export function OpenURLInExtension(arg1:string):Promise<void>;
 
 export function PostItem(arg1:models.Item,arg2:string,arg3:Record<string, any>,arg4:Record<string, any>):Promise<void>;
 
 export function RefreshListings():Promise<void>;
 
-export function SavePropertyMappings(arg1:Array<Record<string, any>>):Promise<void>;
+export function SavePropertyMappings(arg1:models.Item,arg2:Array<Record<string, any>>):Promise<void>;
 
 export function SaveTradingOptions(arg1:Record<string, any>):Promise<void>;
 
+export function ScanAllItems():Promise<Array<models.Item>>;
+
+export function SelectGameInstance(arg1:number):Promise<void>;
+
 export function SetAuthToken(arg1:string):Promise<void>;
 
 export function SetupCookies(arg1:string):Promise<void>;
 
+export function ShowItem(arg1:models.Item):Promise<void>;
+
 export function StartAutoRefresh():Promise<void>;
 
 export function StopAutoRefresh():Promise<void>;
 
 export function TestConnection():Promise<void>;
+
+export function ValidateListing(arg1:models.Item,arg2:Record<string, any>):Promise<Array<listing.Issue>>;
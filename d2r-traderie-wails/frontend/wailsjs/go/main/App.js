@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function CopyItemTooltip(arg1) {
+  return window['go']['main']['App']['CopyItemTooltip'](arg1);
+}
+
 export function FindTraderieItem(arg1) {
   return window['go']['main']['App']['FindTraderieItem'](arg1);
 }
@@ -22,6 +26,14 @@ export function GetCookieSetupInstructions() {
   return window['go']['main']['App']['GetCookieSetupInstructions']();
 }
 
+export function GetGameStatus() {
+  return window['go']['main']['App']['GetGameStatus']();
+}
+
+export function GetItemTooltip(arg1) {
+  return window['go']['main']['App']['GetItemTooltip'](arg1);
+}
+
 export function GetPropertyMapping(arg1) {
   return window['go']['main']['App']['GetPropertyMapping'](arg1);
 }
@@ -34,6 +46,18 @@ export function HasSavedCookies() {
   return window['go']['main']['App']['HasSavedCookies']();
 }
 
+export function ImportItemText(arg1) {
+  return window['go']['main']['App']['ImportItemText'](arg1);
+}
+
+export function ListGameInstances() {
+  return window['go']['main']['App']['ListGameInstances']();
+}
+
+export function OpenSaveFile() {
+  return window['go']['main']['App']['OpenSaveFile']();
+}
+
 export function OpenURLInExtension(arg1) {
   return window['go']['main']['App']['OpenURLInExtension'](arg1);
 }
@@ -46,14 +70,22 @@ export function RefreshListings() {
   return window['go']['main']['App']['RefreshListings']();
 }
 
-export function SavePropertyMappings(arg1) {
-  return window['go']['main']['App']['SavePropertyMappings'](arg1);
+export function SavePropertyMappings(arg1, arg2) {
+  return window['go']['main']['App']['SavePropertyMappings'](arg1, arg2);
 }
 
 export function SaveTradingOptions(arg1) {
   return window['go']['main']['App']['SaveTradingOptions'](arg1);
 }
 
+export function ScanAllItems() {
+  return window['go']['main']['App']['ScanAllItems']();
+}
+
+export function SelectGameInstance(arg1) {
+  return window['go']['main']['App']['SelectGameInstance'](arg1);
+}
+
 export function SetAuthToken(arg1) {
   return window['go']['main']['App']['SetAuthToken'](arg1);
 }
@@ -62,6 +94,10 @@ export function SetupCookies(arg1) {
   return window['go']['main']['App']['SetupCookies'](arg1);
 }
 
+export function ShowItem(arg1) {
+  return window['go']['main']['App']['ShowItem'](arg1);
+}
+
 export function StartAutoRefresh() {
   return window['go']['main']['App']['StartAutoRefresh']();
 }
@@ -73,3 +109,7 @@ export function StopAutoRefresh() {
 export function TestConnection() {
   return window['go']['main']['App']['TestConnection']();
 }
+
+export function ValidateListing(arg1, arg2) {
+  return window['go']['main']['App']['ValidateListing'](arg1, arg2);
+}
@@ -1,9 +1,72 @@
+export namespace listing {
+	
+	export class Issue {
+	    code: string;
+	    severity: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Issue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.severity = source["severity"];
+	        this.reason = source["reason"];
+	    }
+	}
+
+}
+
+export namespace memory {
+	
+	export class SaveFile {
+	    path: string;
+	    character?: models.Character;
+	    items: models.Item[];
+	    warnings?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SaveFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.character = this.convertValues(source["character"], models.Character);
+	        this.items = this.convertValues(source["items"], models.Item);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace models {
 	
 	export class DamageRange {
 	    min: number;
 	    max: number;
 	    type: string;
+	    duration?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new DamageRange(source);
@@ -14,6 +77,7 @@ export namespace models {
 	        this.min = source["min"];
 	        this.max = source["max"];
 	        this.type = source["type"];
+	        this.duration = source["duration"];
 	    }
 	}
 	export class Requirements {
@@ -34,9 +98,53 @@ export namespace models {
 	        this.intelligence = source["intelligence"];
 	    }
 	}
+	export class SkillProc {
+	    skill_id: number;
+	    skill: string;
+	    level: number;
+	    chance?: number;
+	    trigger?: string;
+	    charges?: number;
+	    max_charges?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SkillProc(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.skill_id = source["skill_id"];
+	        this.skill = source["skill"];
+	        this.level = source["level"];
+	        this.chance = source["chance"];
+	        this.trigger = source["trigger"];
+	        this.charges = source["charges"];
+	        this.max_charges = source["max_charges"];
+	    }
+	}
+	export class PerLevel {
+	    raw: number;
+	    per_level: number;
+	    at_level_99: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PerLevel(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.raw = source["raw"];
+	        this.per_level = source["per_level"];
+	        this.at_level_99 = source["at_level_99"];
+	    }
+	}
 	export class Property {
 	    name: string;
 	    value: any;
+	    traderie?: string;
+	    skill?: SkillProc;
+	    damage?: DamageRange;
+	    per_level?: PerLevel;
 	
 	    static createFrom(source: any = {}) {
 	        return new Property(source);
@@ -46,13 +154,203 @@ export namespace models {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
 	        this.value = source["value"];
+	        this.traderie = source["traderie"];
+	        this.skill = this.convertValues(source["skill"], SkillProc);
+	        this.damage = this.convertValues(source["damage"], DamageRange);
+	        this.per_level = this.convertValues(source["per_level"], PerLevel);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Affix {
+	    id: number;
+	    name?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Affix(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	    }
+	}
+	export class Affixes {
+	    prefixes?: Affix[];
+	    suffixes?: Affix[];
+	    rare_prefix?: Affix;
+	    rare_suffix?: Affix;
+	
+	    static createFrom(source: any = {}) {
+	        return new Affixes(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.prefixes = this.convertValues(source["prefixes"], Affix);
+	        this.suffixes = this.convertValues(source["suffixes"], Affix);
+	        this.rare_prefix = this.convertValues(source["rare_prefix"], Affix);
+	        this.rare_suffix = this.convertValues(source["rare_suffix"], Affix);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Stat {
+	    id: number;
+	    layer?: number;
+	    value: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Stat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.layer = source["layer"];
+	        this.value = source["value"];
+	    }
+	}
+	export class SocketedItem {
+	    name: string;
+	    type: string;
+	    properties?: Property[];
+	    stats?: Stat[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SocketedItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.properties = this.convertValues(source["properties"], Property);
+	        this.stats = this.convertValues(source["stats"], Stat);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GameInstance {
+	    pid: number;
+	    character: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GameInstance(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pid = source["pid"];
+	        this.character = source["character"];
+	    }
+	}
+	export class Character {
+	    name: string;
+	    class?: string;
+	    level?: number;
+	    hardcore: boolean;
+	    ladder: boolean;
+	    expansion: boolean;
+	    flags_known: boolean;
+	    offline: boolean;
+	    local_save: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Character(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.class = source["class"];
+	        this.level = source["level"];
+	        this.hardcore = source["hardcore"];
+	        this.ladder = source["ladder"];
+	        this.expansion = source["expansion"];
+	        this.flags_known = source["flags_known"];
+	        this.offline = source["offline"];
+	        this.local_save = source["local_save"];
+	    }
+	}
+	export class ItemLocation {
+	    type: string;
+	    tab?: number;
+	    slot?: string;
+	    x?: number;
+	    y?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ItemLocation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.tab = source["tab"];
+	        this.slot = source["slot"];
+	        this.x = source["x"];
+	        this.y = source["y"];
 	    }
 	}
 	export class Item {
 	    name: string;
+	    base_name?: string;
+	    code?: string;
+	    unit_id?: number;
 	    type: string;
 	    quality: string;
 	    properties: Property[];
+	    stats?: Stat[];
 	    requirements?: Requirements;
 	    sockets: number;
 	    defense?: number;
@@ -60,6 +358,12 @@ export namespace models {
 	    item_level?: number;
 	    is_identified: boolean;
 	    is_ethereal: boolean;
+	    affixes?: Affixes;
+	    socketed_items?: SocketedItem[];
+	    runeword?: string;
+	    location?: ItemLocation;
+	    instance?: GameInstance;
+	    character?: Character;
 	
 	    static createFrom(source: any = {}) {
 	        return new Item(source);
@@ -68,9 +372,13 @@ export namespace models {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
+	        this.base_name = source["base_name"];
+	        this.code = source["code"];
+	        this.unit_id = source["unit_id"];
 	        this.type = source["type"];
 	        this.quality = source["quality"];
 	        this.properties = this.convertValues(source["properties"], Property);
+	        this.stats = this.convertValues(source["stats"], Stat);
 	        this.requirements = this.convertValues(source["requirements"], Requirements);
 	        this.sockets = source["sockets"];
 	        this.defense = source["defense"];
@@ -78,6 +386,12 @@ export namespace models {
 	        this.item_level = source["item_level"];
 	        this.is_identified = source["is_identified"];
 	        this.is_ethereal = source["is_ethereal"];
+	        this.affixes = this.convertValues(source["affixes"], Affixes);
+	        this.socketed_items = this.convertValues(source["socketed_items"], SocketedItem);
+	        this.runeword = source["runeword"];
+	        this.location = this.convertValues(source["location"], ItemLocation);
+	        this.instance = this.convertValues(source["instance"], GameInstance);
+	        this.character = this.convertValues(source["character"], Character);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
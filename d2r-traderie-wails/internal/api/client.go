@@ -20,7 +20,6 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	apiKey     string
-	mapper     *PropertyMapper
 }
 
 // NewClient creates a new Traderie API client
@@ -31,7 +30,6 @@ func NewClient(apiKey string) *Client {
 			Timeout: 30 * time.Second,
 		},
 		apiKey: apiKey,
-		mapper: NewPropertyMapper(),
 	}
 }
 
@@ -41,7 +39,7 @@ func NewClient(apiKey string) *Client {
 func (c *Client) PostItem(item *models.Item, tItem *traderie.TraderieItem, platform, mode string, ladder bool, region string, prices []models.CurrencyGroupPrice, manualMappings []map[string]interface{}, makeOffer bool, itemList *traderie.TraderieItemList) error {
 	log.Printf("Posting item to Traderie (Standard API): %s", item.Name)
 
-	traderieItem := c.mapper.MapItemToTraderie(item, tItem, platform, mode, ladder, region, manualMappings, makeOffer, prices, itemList)
+	traderieItem := MapItemToTraderie(item, tItem, platform, mode, ladder, region, manualMappings, makeOffer, prices, itemList)
 
 	// Create multipart body
 	var b bytes.Buffer
@@ -105,16 +103,6 @@ func (c *Client) PostItem(item *models.Item, tItem *traderie.TraderieItem, platf
 	return nil
 }
 
-// LearnMapping adds a mapping to the internal mapper
-func (c *Client) LearnMapping(d2rProp, traderieProp string) {
-	c.mapper.LearnMapping(d2rProp, traderieProp)
-}
-
-// MapPropertyName maps in-game property name to Traderie format
-func (c *Client) MapPropertyName(inGameText, itemClass string) string {
-	return c.mapper.MapPropertyName(inGameText, itemClass)
-}
-
 // TestConnection tests the connection to Traderie API
 func (c *Client) TestConnection() error {
 	req, err := http.NewRequest("GET", c.baseURL+"/status", nil)
@@ -16,7 +16,6 @@ import (
 // to bypass Cloudflare protection
 type CloudflareClient struct {
 	baseURL string
-	mapper  *PropertyMapper
 	cookies []*http.Cookie
 	apiKey  string // Added to store the Bearer token
 	bridge  *ExtensionBridge
@@ -32,7 +31,6 @@ func NewCloudflareClient(cookies []*http.Cookie, apiKey string, bridge *Extensio
 
 	return &CloudflareClient{
 		baseURL: "https://traderie.com",
-		mapper:  NewPropertyMapper(),
 		cookies: cookies,
 		apiKey:  auth,
 		bridge:  bridge,
@@ -44,7 +42,7 @@ func (c *CloudflareClient) PostItem(item *models.Item, tItem *traderie.TraderieI
 	log.Printf("Posting item to Traderie via extension: %s (ID: %s)", item.Name, tItem.ID)
 
 	// Convert to Traderie format
-	traderieItem := c.mapper.MapItemToTraderie(item, tItem, platform, mode, ladder, region, manualMappings, makeOffer, prices, itemList)
+	traderieItem := MapItemToTraderie(item, tItem, platform, mode, ladder, region, manualMappings, makeOffer, prices, itemList)
 
 	if c.bridge == nil {
 		return fmt.Errorf("extension bridge not initialized")
@@ -72,16 +70,6 @@ func (c *CloudflareClient) PostItem(item *models.Item, tItem *traderie.TraderieI
 	return nil
 }
 
-// LearnMapping adds a mapping to the internal mapper
-func (c *CloudflareClient) LearnMapping(d2rProp, traderieProp string) {
-	c.mapper.LearnMapping(d2rProp, traderieProp)
-}
-
-// MapPropertyName maps in-game property name to Traderie format
-func (c *CloudflareClient) MapPropertyName(inGameText, itemClass string) string {
-	return c.mapper.MapPropertyName(inGameText, itemClass)
-}
-
 // TestConnection tests the connection via the extension
 func (c *CloudflareClient) TestConnection() error {
 	log.Println("Testing Traderie connection via extension...")
@@ -8,20 +8,8 @@ import (
 	"github.com/yourusername/d2r-traderie-wails/pkg/models"
 )
 
-// PropertyMapper maps d2go item properties to Traderie format
-type PropertyMapper struct {
-	propertyMappings map[string]interface{}
-}
-
-// NewPropertyMapper creates a new property mapper
-func NewPropertyMapper() *PropertyMapper {
-	return &PropertyMapper{
-		propertyMappings: getPropertyMappings(),
-	}
-}
-
 // MapItemToTraderie converts an item to Traderie API format (listings/create)
-func (pm *PropertyMapper) MapItemToTraderie(
+func MapItemToTraderie(
 	item *models.Item,
 	tItem *traderie.TraderieItem,
 	platform, mode string,
@@ -131,7 +119,7 @@ func (pm *PropertyMapper) MapItemToTraderie(
 					}
 
 					if val == nil {
-						val = pm.extractNumericValue(d2rProp)
+						val = extractNumericValue(d2rProp)
 					}
 
 					if val != nil {
@@ -248,7 +236,7 @@ func (pm *PropertyMapper) MapItemToTraderie(
 }
 
 // extractNumericValue helper to get a number from a property string
-func (pm *PropertyMapper) extractNumericValue(s string) interface{} {
+func extractNumericValue(s string) interface{} {
 	// Look for ranges first (e.g. 3-32)
 	if strings.Contains(s, "-") {
 		parts := strings.Split(s, "-")
@@ -271,267 +259,3 @@ func (pm *PropertyMapper) extractNumericValue(s string) interface{} {
 	}
 	return nil
 }
-
-// MapPropertyName maps in-game property name to Traderie format
-func (pm *PropertyMapper) MapPropertyName(inGameText, itemClass string) string {
-	// Clean up the in-game text
-	cleanInGame := inGameText
-	if idx := strings.Index(inGameText, ":"); idx != -1 {
-		cleanInGame = strings.TrimSpace(inGameText[:idx])
-	}
-
-	normalized := normalizeText(cleanInGame)
-
-	// Try exact match first
-	for key, value := range pm.propertyMappings {
-		if normalizeText(key) == normalized {
-			return pm.resolveMapping(value, itemClass)
-		}
-	}
-
-	// Try partial match
-	for key, value := range pm.propertyMappings {
-		normalizedKey := normalizeText(key)
-		if strings.Contains(normalized, normalizedKey) || strings.Contains(normalizedKey, normalized) {
-			return pm.resolveMapping(value, itemClass)
-		}
-	}
-
-	return ""
-}
-
-// LearnMapping adds a new mapping to the mapper
-func (pm *PropertyMapper) LearnMapping(d2rProp, traderieProp string) {
-	cleanD2R := d2rProp
-	if idx := strings.Index(d2rProp, ":"); idx != -1 {
-		cleanD2R = strings.TrimSpace(d2rProp[:idx])
-	}
-	
-	pm.propertyMappings[cleanD2R] = traderieProp
-}
-
-// resolveMapping handles class-specific skill mappings
-func (pm *PropertyMapper) resolveMapping(value interface{}, itemClass string) string {
-	switch v := value.(type) {
-	case string:
-		return v
-	case map[string]string:
-		if itemClass != "" && v[itemClass] != "" {
-			return v[itemClass]
-		}
-		if v["any"] != "" {
-			return v["any"]
-		}
-	}
-	return ""
-}
-
-// mapCategory converts item type to Traderie category
-func (pm *PropertyMapper) mapCategory(itemType string) string {
-	categoryMap := map[string]string{
-		"Ring":    "jewelry",
-		"Amulet":  "jewelry",
-		"Charm":   "charms",
-		"Jewel":   "jewels",
-		"Rune":    "runes",
-		"Weapon":  "weapons",
-		"Armor":   "armor",
-		"Helm":    "armor",
-		"Shield":  "armor",
-		"Gloves":  "armor",
-		"Belt":    "armor",
-		"Boots":   "armor",
-	}
-
-	if category, ok := categoryMap[itemType]; ok {
-		return category
-	}
-	return "other"
-}
-
-// mapQuality converts item quality to Traderie format
-func (pm *PropertyMapper) mapQuality(quality string) string {
-	qualityMap := map[string]string{
-		"Unique":  "unique",
-		"Set":     "set",
-		"Rare":    "rare",
-		"Magic":   "magic",
-		"Crafted": "crafted",
-		"Normal":  "normal",
-	}
-
-	if q, ok := qualityMap[quality]; ok {
-		return q
-	}
-	return "magic"
-}
-
-// normalizeText standardizes text for comparison
-func normalizeText(text string) string {
-	text = strings.ToLower(text)
-	text = strings.TrimSpace(text)
-	
-	// Remove special characters except spaces
-	var result strings.Builder
-	for _, r := range text {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == ' ' {
-			result.WriteRune(r)
-		} else {
-			result.WriteRune(' ')
-		}
-	}
-	
-	// Normalize multiple spaces
-	normalized := result.String()
-	normalized = strings.Join(strings.Fields(normalized), " ")
-	
-	return normalized
-}
-
-// getPropertyMappings returns the property mapping table
-func getPropertyMappings() map[string]interface{} {
-	return map[string]interface{}{
-		// Skills - Barbarian
-		"Battle Orders": map[string]string{
-			"barbarian": "to Battle Orders (Barbarian Only)",
-			"any":       "to Battle Orders (Any Class)",
-		},
-		"Battle Command": map[string]string{
-			"barbarian": "to Battle Command (Barbarian Only)",
-			"any":       "to Battle Command (Any Class)",
-		},
-		"Whirlwind": map[string]string{
-			"barbarian": "to Whirlwind (Barbarian Only)",
-			"any":       "to Whirlwind (Any Class)",
-		},
-		"Berserk": map[string]string{
-			"barbarian": "to Berserk (Barbarian Only)",
-			"any":       "to Berserk (Any Class)",
-		},
-		
-		// Skills - Sorceress
-		"Fireball": map[string]string{
-			"sorceress": "to Fireball (Sorceress Only)",
-			"any":       "to Fireball (Any Class)",
-		},
-		"Fire Ball": map[string]string{
-			"sorceress": "to Fireball (Sorceress Only)",
-			"any":       "to Fireball (Any Class)",
-		},
-		"Frozen Orb": map[string]string{
-			"sorceress": "to Frozen Orb (Sorceress Only)",
-			"any":       "to Frozen Orb (Any Class)",
-		},
-		"Blizzard": map[string]string{
-			"sorceress": "to Blizzard (Sorceress Only)",
-			"any":       "to Blizzard (Any Class)",
-		},
-		"Chain Lightning": map[string]string{
-			"sorceress": "to Chain Lightning (Sorceress Only)",
-			"any":       "to Chain Lightning (Any Class)",
-		},
-		"Lightning": map[string]string{
-			"sorceress": "to Lightning (Sorceress Only)",
-			"any":       "to Lightning (Any Class)",
-		},
-		"Teleport": map[string]string{
-			"sorceress": "to Teleport (Sorceress Only)",
-			"any":       "to Teleport (Any Class)",
-		},
-		
-		// Skills - Paladin
-		"Holy Shield": map[string]string{
-			"paladin": "to Holy Shield (Paladin Only)",
-			"any":     "to Holy Shield (Any Class)",
-		},
-		"Blessed Hammer": map[string]string{
-			"paladin": "to Blessed Hammer (Paladin Only)",
-			"any":     "to Blessed Hammer (Any Class)",
-		},
-		"Concentration": map[string]string{
-			"paladin": "to Concentration (Paladin Only)",
-			"any":     "to Concentration (Any Class)",
-		},
-		"Fanaticism": map[string]string{
-			"paladin": "to Fanaticism (Paladin Only)",
-			"any":     "to Fanaticism (Any Class)",
-		},
-		"Conviction": map[string]string{
-			"paladin": "to Conviction (Paladin Only)",
-			"any":     "to Conviction (Any Class)",
-		},
-		
-		// General skill modifiers
-		"All Skills":                "to All Skills",
-		"All Skill Levels":          "to All Skills",
-		"Barbarian Skill Levels":    "to Barbarian Skill Levels",
-		"Sorceress Skill Levels":    "to Sorceress Skill",
-		"Paladin Skill Levels":      "to Paladin Skill",
-		"Necromancer Skill Levels":  "to Necromancer Skill",
-		"Amazon Skill Levels":       "to Amazon Skill",
-		"Druid Skill Levels":        "to Druid Skill",
-		"Assassin Skill Levels":     "to Assassin Skill",
-		"Lightning Damage":          "Lightning Damage",
-		"Adds Lightning Damage":     "Lightning Damage",
-		
-		// Stats
-		"Faster Cast Rate":            "% Faster Cast Rate",
-		"Faster Hit Recovery":         "% Faster Hit Recovery",
-		"Increased Attack Speed":      "% Increased Attack Speed",
-		"Faster Run/Walk":             "% Faster Run/Walk",
-		"Faster Block Rate":           "% Faster Block Rate",
-		"Enhanced Damage":             "% Enhanced Damage",
-		"Enhanced Defense":            "% Enhanced Defense",
-		"Increased Maximum Durability": "% Increased Maximum Durability",
-		
-		// Life and Mana
-		"Life":         "to Life",
-		"Maximum Life": "to Life",
-		"Mana":         "to Mana",
-		"Maximum Mana": "to Mana",
-		
-		// Attributes
-		"Strength":  "to Strength",
-		"Dexterity": "to Dexterity",
-		"Vitality":  "to Vitality",
-		"Energy":    "to Energy",
-		
-		// Stealing
-		"Life Stolen Per Hit": "% Life Stolen Per Hit",
-		"Mana Stolen Per Hit": "% Mana Stolen Per Hit",
-		
-		// Resistances
-		"All Resistances":   "to All Resistances",
-		"Fire Resist":       "% Fire Resist",
-		"Cold Resist":       "% Cold Resist",
-		"Lightning Resist":  "% Lightning Resist",
-		"Poison Resist":     "% Poison Resist",
-		
-		// Magic Find
-		"Magic Find":                           "% Better Chance of Getting Magic Items",
-		"Better Chance of Getting Magic Items": "% Better Chance of Getting Magic Items",
-		
-		// Combat modifiers
-		"Deadly Strike":       "% Deadly Strike",
-		"Crushing Blow":       "% Crushing Blow",
-		"Open Wounds":         "% Open Wounds",
-		"Chance of Open Wounds": "% Open Wounds",
-		
-		// Other
-		"Defense":               "Defense",
-		"Damage":                "Damage",
-		"Attack Rating":         "to Attack Rating",
-		"Bonus to Attack Rating": "to Attack Rating",
-		"Sockets":               "Sockets",
-		"Socket":                "Sockets",
-		"Replenish Life":        "Replenish Life",
-		"Regenerate Mana":       "Regenerate Mana",
-		"Damage Reduced":        "Damage Reduced",
-		"Magic Damage Reduced":  "Magic Damage Reduced",
-		"Cannot Be Frozen":      "Cannot Be Frozen",
-		"Ignore Target Defense": "Ignore Target's Defense",
-		"Prevent Monster Heal":  "Prevent Monster Heal",
-		"Half Freeze Duration":  "Half Freeze Duration",
-		"Light Radius":          "to Light Radius",
-	}
-}
@@ -8,7 +8,7 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	// Hotkey for capturing items (e.g., "F9", "Ctrl+Shift+P")
+	// Hotkey for capturing items, F1-F12 (e.g., "F9")
 	Hotkey string `json:"hotkey"`
 
 	// Traderie API configuration
@@ -20,6 +20,9 @@ type Config struct {
 	// Notification settings
 	Notifications NotificationConfig `json:"notifications"`
 
+	// Memory reading settings
+	Memory MemoryConfig `json:"memory"`
+
 	// Logging level (debug, info, warn, error)
 	LogLevel string `json:"log_level"`
 }
@@ -89,6 +92,21 @@ type NotificationConfig struct {
 	AutoDismissSeconds int `json:"auto_dismiss_seconds"`
 }
 
+// MemoryConfig holds settings for where scanned items come from
+type MemoryConfig struct {
+	// Replay recorded snapshots from this file or directory instead of reading D2R memory
+	ReplayPath string `json:"replay_path,omitempty"`
+
+	// Record the raw game data behind every scan to capture files
+	RecordScans bool `json:"record_scans"`
+
+	// Extra hotkeys that scan from a specific character's game (hotkey -> character name)
+	InstanceHotkeys map[string]string `json:"instance_hotkeys,omitempty"`
+
+	// Directory of extracted D2R excel .txt files (default: the excel folder in the data directory)
+	GameDataPath string `json:"game_data_path,omitempty"`
+}
+
 // Default returns the default configuration
 func Default() *Config {
 	return &Config{
@@ -170,12 +188,17 @@ func (c *Config) Save() error {
 
 // getConfigPath returns the path to the config file
 func getConfigPath() string {
+	return filepath.Join(DataDir(), "config.json")
+}
+
+// DataDir returns the directory holding config, cookies and other app data
+func DataDir() string {
 	// Use user's home directory
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		homeDir = "."
 	}
 
-	return filepath.Join(homeDir, ".d2r-traderie", "config.json")
+	return filepath.Join(homeDir, ".d2r-traderie")
 }
 
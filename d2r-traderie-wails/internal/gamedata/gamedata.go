@@ -0,0 +1,996 @@
+package gamedata
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/config"
+)
+
+// Game tables placed in excel/ are embedded in the build. The item, affix
+// and item type tables are unmodified copies; runes.txt, gems.txt,
+// itemstatcost.txt, properties.txt and item-modifiers.json are transcribed
+// by hand. Tables
+// extracted from the player's D2R install into OverrideDir replace them.
+//
+//go:embed excel
+var embeddedTables embed.FS
+
+// OverrideDir is the directory in the data directory that holds extracted excel files
+const OverrideDir = "excel"
+
+// Excel file names, as extracted from the game's data/global/excel folder
+const (
+	FileArmor        = "armor.txt"
+	FileWeapons      = "weapons.txt"
+	FileMisc         = "misc.txt"
+	FileUniqueItems  = "uniqueitems.txt"
+	FileSetItems     = "setitems.txt"
+	FileRunes        = "runes.txt"
+	FileGems         = "gems.txt"
+	FileMagicPrefix  = "magicprefix.txt"
+	FileMagicSuffix  = "magicsuffix.txt"
+	FileRarePrefix   = "rareprefix.txt"
+	FileRareSuffix   = "raresuffix.txt"
+	FileItemStatCost = "itemstatcost.txt"
+	FileItemTypes    = "itemtypes.txt"
+	FileProperties   = "properties.txt"
+
+	// FileItemModifiers holds the tooltip strings ItemStatCost's description
+	// columns refer to (from data/local/lng/strings)
+	FileItemModifiers = "item-modifiers.json"
+)
+
+// SourceEmbedded is the source reported for tables read from the embedded copy
+const SourceEmbedded = "embedded"
+
+// Tables lists every file the loader reads
+var Tables = []string{
+	FileArmor, FileWeapons, FileMisc, FileUniqueItems, FileSetItems, FileRunes, FileGems,
+	FileMagicPrefix, FileMagicSuffix, FileRarePrefix, FileRareSuffix, FileItemStatCost,
+	FileItemTypes, FileProperties, FileItemModifiers,
+}
+
+// ItemBase is a base item from Armor.txt, Weapons.txt or Misc.txt
+type ItemBase struct {
+	Code     string
+	Name     string
+	Kind     string // armor, weapon or misc
+	Type     string // item type code, e.g. "tors", "swor", "rune"
+	Type2    string
+	Level    int // quality level
+	LevelReq int
+	ReqStr   int
+	ReqDex   int
+
+	MinAC int
+	MaxAC int
+
+	MinDamage        int
+	MaxDamage        int
+	TwoHandMinDamage int
+	TwoHandMaxDamage int
+	TwoHanded        bool
+
+	Durability int
+	MaxSockets int
+	Stackable  bool
+	Quest      bool // quest items can't be traded
+
+	// Codes of the normal, exceptional and elite versions of the base
+	NormCode  string
+	UberCode  string
+	UltraCode string
+}
+
+// Tier returns "normal", "exceptional" or "elite"
+func (b ItemBase) Tier() string {
+	switch {
+	case b.UltraCode != "" && b.Code == b.UltraCode:
+		return "elite"
+	case b.UberCode != "" && b.Code == b.UberCode:
+		return "exceptional"
+	}
+	return "normal"
+}
+
+// Mod is one property an item, runeword or affix adds, using Properties.txt codes
+type Mod struct {
+	Property string // e.g. "res-all", "allskills", "dmg%"
+	Param    string // skill, class or tab, depending on the property
+	Min      int
+	Max      int
+}
+
+// UniqueItem is a row of UniqueItems.txt
+type UniqueItem struct {
+	ID       int
+	Name     string
+	Code     string // base item code
+	Level    int
+	LevelReq int
+	Mods     []Mod
+}
+
+// SetItem is a row of SetItems.txt
+type SetItem struct {
+	ID       int
+	Name     string
+	Set      string
+	Code     string // base item code
+	Level    int
+	LevelReq int
+	Mods     []Mod
+}
+
+// Runeword is a row of Runes.txt
+type Runeword struct {
+	Key          string // internal name, e.g. "Runeword65"
+	Name         string // display name, e.g. "Enigma"
+	Complete     bool   // false for recipes that never made it into the game
+	Types        []string
+	ExcludeTypes []string
+	Runes        []string // rune item codes in socket order, e.g. "r31"
+	Mods         []Mod
+}
+
+// Gem is a row of Gems.txt: a gem or rune and the fixed stats it adds to
+// the item it is socketed in
+type Gem struct {
+	Code   string
+	Name   string
+	Weapon []Mod
+	Helm   []Mod // helms and body armor
+	Shield []Mod
+}
+
+// Affix is a row of MagicPrefix.txt, MagicSuffix.txt, RarePrefix.txt or RareSuffix.txt
+type Affix struct {
+	ID           int
+	Name         string
+	Spawnable    bool
+	Rare         bool // can also roll on rare items
+	Level        int
+	MaxLevel     int
+	LevelReq     int
+	Group        int // affixes in the same group exclude each other
+	Types        []string
+	ExcludeTypes []string
+	Mods         []Mod
+}
+
+// StatCost is a row of ItemStatCost.txt
+type StatCost struct {
+	ID            int
+	Stat          string
+	Signed        bool
+	SaveBits      int
+	SaveAdd       int
+	SaveParamBits int
+	Op            int
+	OpParam       int
+
+	// Tooltip description
+	DescPriority int
+	DescFunc     int
+	DescVal      int
+	DescStrPos   string
+	DescStrNeg   string
+	DescStr2     string
+
+	// Description used when every stat of the group has the same value
+	DescGroup       int
+	DescGroupFunc   int
+	DescGroupVal    int
+	DescGroupStrPos string
+	DescGroupStrNeg string
+	DescGroupStr2   string
+}
+
+// Data holds the parsed and indexed game tables
+type Data struct {
+	bases       map[string]ItemBase
+	basesByName map[string]string
+
+	uniques       []UniqueItem
+	uniquesByName map[string][]int // several rows share some names, like the Rainbow Facets
+	setItems      []SetItem
+	setsByName    map[string]int
+	runewords     []Runeword
+	gems          map[string]Gem
+
+	magicPrefixes map[int]Affix
+	magicSuffixes map[int]Affix
+	rarePrefixes  []Affix // rows of RarePrefix.txt, see rareName
+	rareSuffixes  []Affix // rows of RareSuffix.txt
+
+	stats       map[int]StatCost
+	statsByName map[string]int
+
+	typeParents map[string][]string       // item type code -> the types it counts as, from Equiv1/Equiv2
+	properties  map[string][]propertyStat // property code -> the ItemStatCost stats it sets
+
+	strings map[string]string // string key -> English text
+
+	sources map[string]string
+}
+
+var (
+	defaultData *Data
+	defaultMu   sync.Mutex
+)
+
+// Default returns the installed game data. Unless SetDefault was called it
+// loads the excel directory in the data directory over the embedded copy,
+// falling back to the embedded copy alone when that fails. Tables found in
+// neither are empty; see Missing.
+func Default() *Data {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultData == nil {
+		dir := filepath.Join(config.DataDir(), OverrideDir)
+		data, err := Load(dir)
+		if err != nil {
+			log.Printf("⚠️ Failed to load game data from %s: %v", dir, err)
+			data, _ = Load("")
+		}
+		defaultData = data
+	}
+	return defaultData
+}
+
+// SetDefault replaces the game data returned by Default
+func SetDefault(data *Data) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultData = data
+}
+
+// Load parses the game tables, taking each file from dir when it is there
+// and from the embedded copy otherwise. An empty dir loads only the embedded copy.
+func Load(dir string) (*Data, error) {
+	d := &Data{
+		bases:         make(map[string]ItemBase),
+		basesByName:   make(map[string]string),
+		uniquesByName: make(map[string][]int),
+		setsByName:    make(map[string]int),
+		gems:          make(map[string]Gem),
+		magicPrefixes: make(map[int]Affix),
+		magicSuffixes: make(map[int]Affix),
+		stats:         make(map[int]StatCost),
+		statsByName:   make(map[string]int),
+		typeParents:   make(map[string][]string),
+		properties:    make(map[string][]propertyStat),
+		strings:       make(map[string]string),
+		sources:       make(map[string]string),
+	}
+
+	loaders := []struct {
+		file string
+		load func(*table)
+	}{
+		{FileArmor, func(t *table) { d.loadBases(t, "armor") }},
+		{FileWeapons, func(t *table) { d.loadBases(t, "weapon") }},
+		{FileMisc, func(t *table) { d.loadBases(t, "misc") }},
+		{FileUniqueItems, d.loadUniques},
+		{FileSetItems, d.loadSetItems},
+		{FileRunes, d.loadRunewords},
+		{FileGems, d.loadGems},
+		{FileMagicPrefix, func(t *table) { loadAffixes(t, d.magicPrefixes) }},
+		{FileMagicSuffix, func(t *table) { loadAffixes(t, d.magicSuffixes) }},
+		{FileRarePrefix, func(t *table) { d.rarePrefixes = loadRareAffixes(t) }},
+		{FileRareSuffix, func(t *table) { d.rareSuffixes = loadRareAffixes(t) }},
+		{FileItemStatCost, d.loadStats},
+		{FileItemTypes, d.loadItemTypes},
+		{FileProperties, d.loadProperties},
+	}
+
+	extracted := 0
+	for _, loader := range loaders {
+		t, source, err := openTable(dir, loader.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", loader.file, err)
+		}
+		if t == nil {
+			continue
+		}
+
+		loader.load(t)
+		d.sources[loader.file] = source
+		if source != SourceEmbedded {
+			extracted++
+		}
+	}
+
+	// Rare name words are numbered across both tables, see rareName
+	for i := range d.rareSuffixes {
+		d.rareSuffixes[i].ID = i + 1
+	}
+	for i := range d.rarePrefixes {
+		d.rarePrefixes[i].ID = len(d.rareSuffixes) + i + 1
+	}
+
+	raw, source, err := openFile(dir, FileItemModifiers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileItemModifiers, err)
+	}
+	if raw != nil {
+		if err := d.loadStrings(raw); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", FileItemModifiers, err)
+		}
+		d.sources[FileItemModifiers] = source
+		if source != SourceEmbedded {
+			extracted++
+		}
+	}
+
+	if extracted > 0 {
+		log.Printf("✓ Loaded %d game data tables from %s", extracted, dir)
+	}
+	return d, nil
+}
+
+// openTable parses a table from dir, falling back to the embedded copy. It
+// returns a nil table when neither has the file.
+func openTable(dir, file string) (*table, string, error) {
+	raw, source, err := openFile(dir, file)
+	if err != nil || raw == nil {
+		return nil, "", err
+	}
+
+	t, err := parseTable(bytes.NewReader(raw))
+	return t, source, err
+}
+
+// openFile reads a file from dir, falling back to the embedded copy. It
+// returns nil when neither has the file.
+func openFile(dir, file string) ([]byte, string, error) {
+	if dir != "" {
+		path, err := findFile(dir, file)
+		if err != nil {
+			return nil, "", err
+		}
+		if path != "" {
+			raw, err := os.ReadFile(path)
+			return raw, path, err
+		}
+	}
+
+	raw, err := fs.ReadFile(embeddedTables, "excel/"+file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", nil
+	}
+	return raw, SourceEmbedded, err
+}
+
+// findFile looks for a file in dir ignoring case, since extraction tools
+// disagree on "Armor.txt" vs "armor.txt". A missing dir is not an error.
+func findFile(dir, file string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(entry.Name(), file) {
+			return filepath.Join(dir, entry.Name()), nil
+		}
+	}
+	return "", nil
+}
+
+// readMods collects numbered property columns, e.g. prop1/par1/min1/max1
+func readMods(r row, code, param, minColumn, maxColumn string, count int) []Mod {
+	var mods []Mod
+	for i := 1; i <= count; i++ {
+		property := r.str(fmt.Sprintf(code, i))
+		if property == "" {
+			continue
+		}
+		mods = append(mods, Mod{
+			Property: property,
+			Param:    r.str(fmt.Sprintf(param, i)),
+			Min:      r.int(fmt.Sprintf(minColumn, i)),
+			Max:      r.int(fmt.Sprintf(maxColumn, i)),
+		})
+	}
+	return mods
+}
+
+func (d *Data) loadBases(t *table, kind string) {
+	for _, r := range t.rows {
+		if r.separator("code") {
+			continue
+		}
+
+		base := ItemBase{
+			Code:             r.str("code"),
+			Name:             r.str("name"),
+			Kind:             kind,
+			Type:             r.str("type"),
+			Type2:            r.str("type2"),
+			Level:            r.int("level"),
+			LevelReq:         r.int("levelreq"),
+			ReqStr:           r.int("reqstr"),
+			ReqDex:           r.int("reqdex"),
+			MinAC:            r.int("minac"),
+			MaxAC:            r.int("maxac"),
+			MinDamage:        r.int("mindam"),
+			MaxDamage:        r.int("maxdam"),
+			TwoHandMinDamage: r.int("2handmindam"),
+			TwoHandMaxDamage: r.int("2handmaxdam"),
+			TwoHanded:        r.bool("2handed"),
+			Durability:       r.int("durability"),
+			MaxSockets:       r.int("gemsockets"),
+			Stackable:        r.bool("stackable"),
+			Quest:            r.bool("quest"),
+			NormCode:         r.str("normcode"),
+			UberCode:         r.str("ubercode"),
+			UltraCode:        r.str("ultracode"),
+		}
+
+		d.bases[base.Code] = base
+		if base.Name != "" {
+			d.basesByName[strings.ToLower(base.Name)] = base.Code
+		}
+	}
+}
+
+func (d *Data) loadUniques(t *table) {
+	for i, r := range t.rows {
+		if r.separator("index") {
+			continue
+		}
+
+		name := strings.ToLower(r.str("index"))
+		d.uniquesByName[name] = append(d.uniquesByName[name], len(d.uniques))
+		d.uniques = append(d.uniques, UniqueItem{
+			ID:       r.id(i),
+			Name:     r.str("index"),
+			Code:     r.str("code"),
+			Level:    r.int("lvl"),
+			LevelReq: r.int("lvl req"),
+			Mods:     readMods(r, "prop%d", "par%d", "min%d", "max%d", 12),
+		})
+	}
+}
+
+func (d *Data) loadSetItems(t *table) {
+	for i, r := range t.rows {
+		if r.separator("index") {
+			continue
+		}
+
+		d.setsByName[strings.ToLower(r.str("index"))] = len(d.setItems)
+		d.setItems = append(d.setItems, SetItem{
+			ID:       r.id(i),
+			Name:     r.str("index"),
+			Set:      r.str("set"),
+			Code:     r.str("item"),
+			Level:    r.int("lvl"),
+			LevelReq: r.int("lvl req"),
+			Mods:     readMods(r, "prop%d", "par%d", "min%d", "max%d", 9),
+		})
+	}
+}
+
+func (d *Data) loadRunewords(t *table) {
+	for _, r := range t.rows {
+		if r.separator("name") {
+			continue
+		}
+
+		name := r.str("*Rune Name")
+		if name == "" {
+			name = r.str("Rune Name")
+		}
+		if name == "" {
+			name = r.str("name")
+		}
+
+		d.runewords = append(d.runewords, Runeword{
+			Key:          r.str("name"),
+			Name:         name,
+			Complete:     r.bool("complete"),
+			Types:        r.list("itype%d", 6),
+			ExcludeTypes: r.list("etype%d", 3),
+			Runes:        r.list("Rune%d", 6),
+			Mods:         readMods(r, "T1Code%d", "T1Param%d", "T1Min%d", "T1Max%d", 7),
+		})
+	}
+}
+
+func (d *Data) loadGems(t *table) {
+	for _, r := range t.rows {
+		if r.separator("code") {
+			continue
+		}
+
+		gem := Gem{
+			Code:   r.str("code"),
+			Name:   r.str("name"),
+			Weapon: readMods(r, "weaponMod%dCode", "weaponMod%dParam", "weaponMod%dMin", "weaponMod%dMax", 3),
+			Helm:   readMods(r, "helmMod%dCode", "helmMod%dParam", "helmMod%dMin", "helmMod%dMax", 3),
+			Shield: readMods(r, "shieldMod%dCode", "shieldMod%dParam", "shieldMod%dMin", "shieldMod%dMax", 3),
+		}
+		d.gems[gem.Code] = gem
+	}
+}
+
+// loadAffixes indexes affix rows by position, which is the ID the game stores on items
+func loadAffixes(t *table, into map[int]Affix) {
+	for i, r := range t.rows {
+		if r.separator("name") {
+			continue
+		}
+
+		id := r.id(i)
+		into[id] = readAffix(r, id)
+	}
+}
+
+// loadRareAffixes lists the rows of a rare name table in order. Separator
+// rows stay as empty entries so the rows after them keep their position.
+func loadRareAffixes(t *table) []Affix {
+	affixes := make([]Affix, len(t.rows))
+	for i, r := range t.rows {
+		if !r.separator("name") {
+			affixes[i] = readAffix(r, 0)
+		}
+	}
+	return affixes
+}
+
+func readAffix(r row, id int) Affix {
+	return Affix{
+		ID:           id,
+		Name:         r.str("name"),
+		Spawnable:    r.bool("spawnable"),
+		Rare:         r.bool("rare"),
+		Level:        r.int("level"),
+		MaxLevel:     r.int("maxlevel"),
+		LevelReq:     r.int("levelreq"),
+		Group:        r.int("group"),
+		Types:        r.list("itype%d", 7),
+		ExcludeTypes: r.list("etype%d", 5),
+		Mods:         readMods(r, "mod%dcode", "mod%dparam", "mod%dmin", "mod%dmax", 3),
+	}
+}
+
+func (d *Data) loadStats(t *table) {
+	for i, r := range t.rows {
+		if r.separator("stat") {
+			continue
+		}
+
+		s := StatCost{
+			ID:              r.id(i),
+			Stat:            r.str("stat"),
+			Signed:          r.bool("signed"),
+			SaveBits:        r.int("save bits"),
+			SaveAdd:         r.int("save add"),
+			SaveParamBits:   r.int("save param bits"),
+			Op:              r.int("op"),
+			OpParam:         r.int("op param"),
+			DescPriority:    r.int("descpriority"),
+			DescFunc:        r.int("descfunc"),
+			DescVal:         r.int("descval"),
+			DescStrPos:      r.str("descstrpos"),
+			DescStrNeg:      r.str("descstrneg"),
+			DescStr2:        r.str("descstr2"),
+			DescGroup:       r.int("dgrp"),
+			DescGroupFunc:   r.int("dgrpfunc"),
+			DescGroupVal:    r.int("dgrpval"),
+			DescGroupStrPos: r.str("dgrpstrpos"),
+			DescGroupStrNeg: r.str("dgrpstrneg"),
+			DescGroupStr2:   r.str("dgrpstr2"),
+		}
+
+		d.stats[s.ID] = s
+		d.statsByName[strings.ToLower(s.Stat)] = s.ID
+	}
+}
+
+func (d *Data) loadItemTypes(t *table) {
+	for _, r := range t.rows {
+		if r.separator("code") {
+			continue
+		}
+		d.typeParents[r.str("code")] = r.list("equiv%d", 2)
+	}
+}
+
+// rangeFuncs are the Properties.txt functions that set a stat from the min,
+// max or param column, like the two ends of "Adds 15-45 Fire Damage",
+// rather than from the rolled value
+var rangeFuncs = map[int]bool{15: true, 16: true, 17: true}
+
+// propertyStat is a stat a Properties.txt row sets, with the function setting it
+type propertyStat struct {
+	stat string
+	fn   int
+}
+
+func (d *Data) loadProperties(t *table) {
+	for _, r := range t.rows {
+		if r.separator("code") {
+			continue
+		}
+
+		var stats []propertyStat
+		for i := 1; i <= 7; i++ {
+			if stat := r.str(fmt.Sprintf("stat%d", i)); stat != "" {
+				stats = append(stats, propertyStat{stat: stat, fn: r.int(fmt.Sprintf("func%d", i))})
+			}
+		}
+		d.properties[r.str("code")] = stats
+	}
+}
+
+// loadStrings reads a D2R string file: a JSON array of {"Key": ..., "enUS": ...} objects
+func (d *Data) loadStrings(raw []byte) error {
+	// Strip the byte order mark some extraction tools leave in place
+	raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))
+
+	var entries []struct {
+		Key  string `json:"Key"`
+		EnUS string `json:"enUS"`
+	}
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		d.strings[entry.Key] = entry.EnUS
+	}
+	return nil
+}
+
+// Source returns where a table was loaded from: a file path, SourceEmbedded,
+// or "" when it wasn't loaded
+func (d *Data) Source(file string) string {
+	return d.sources[file]
+}
+
+// Missing lists the tables that were found neither in the extracted copy
+// nor embedded in the build
+func (d *Data) Missing() []string {
+	var missing []string
+	for _, file := range Tables {
+		if d.sources[file] == "" {
+			missing = append(missing, file)
+		}
+	}
+	return missing
+}
+
+// Extracted reports whether a table came from an extracted copy rather than the embedded one
+func (d *Data) Extracted(file string) bool {
+	source := d.sources[file]
+	return source != "" && source != SourceEmbedded
+}
+
+// Base returns the base item with the given code
+func (d *Data) Base(code string) (ItemBase, bool) {
+	base, ok := d.bases[code]
+	return base, ok
+}
+
+// BaseByName returns the base item with the given name, ignoring case
+func (d *Data) BaseByName(name string) (ItemBase, bool) {
+	code, ok := d.basesByName[strings.ToLower(name)]
+	if !ok {
+		return ItemBase{}, false
+	}
+	return d.Base(code)
+}
+
+// Bases returns every base item, ordered by code
+func (d *Data) Bases() []ItemBase {
+	bases := make([]ItemBase, 0, len(d.bases))
+	for _, base := range d.bases {
+		bases = append(bases, base)
+	}
+	sort.Slice(bases, func(i, j int) bool { return bases[i].Code < bases[j].Code })
+	return bases
+}
+
+// Unique returns the first unique item with the given name, ignoring case.
+// Use Uniques where the row matters.
+func (d *Data) Unique(name string) (UniqueItem, bool) {
+	rows := d.uniquesByName[strings.ToLower(name)]
+	if len(rows) == 0 {
+		return UniqueItem{}, false
+	}
+	return d.uniques[rows[0]], true
+}
+
+// Uniques returns every unique item with the given name, ignoring case, in
+// table order. The Rainbow Facets and Azurewrath have more than one row.
+func (d *Data) Uniques(name string) []UniqueItem {
+	var uniques []UniqueItem
+	for _, i := range d.uniquesByName[strings.ToLower(name)] {
+		uniques = append(uniques, d.uniques[i])
+	}
+	return uniques
+}
+
+// UniqueByID returns the unique item with the given ID
+func (d *Data) UniqueByID(id int) (UniqueItem, bool) {
+	for _, u := range d.uniques {
+		if u.ID == id {
+			return u, true
+		}
+	}
+	return UniqueItem{}, false
+}
+
+// SetItem returns the set item with the given name, ignoring case
+func (d *Data) SetItem(name string) (SetItem, bool) {
+	i, ok := d.setsByName[strings.ToLower(name)]
+	if !ok {
+		return SetItem{}, false
+	}
+	return d.setItems[i], true
+}
+
+// SetItemByID returns the set item with the given ID
+func (d *Data) SetItemByID(id int) (SetItem, bool) {
+	for _, s := range d.setItems {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return SetItem{}, false
+}
+
+// Runeword returns the runeword with the given display name, ignoring case
+func (d *Data) Runeword(name string) (Runeword, bool) {
+	for _, rw := range d.runewords {
+		if strings.EqualFold(rw.Name, name) {
+			return rw, true
+		}
+	}
+	return Runeword{}, false
+}
+
+// RunewordByRunes returns the complete runeword spelled by rune codes in socket order
+func (d *Data) RunewordByRunes(codes []string) (Runeword, bool) {
+	for _, rw := range d.runewords {
+		if !rw.Complete || len(rw.Runes) != len(codes) {
+			continue
+		}
+
+		match := true
+		for i := range codes {
+			if !strings.EqualFold(rw.Runes[i], codes[i]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return rw, true
+		}
+	}
+	return Runeword{}, false
+}
+
+// MatchesTypes reports whether an item type is one of include, or counts as
+// one through ItemTypes' Equiv columns, and isn't one of exclude. Runewords
+// and affixes list the types they can spawn on this way, e.g. "weap" or "helm".
+func (d *Data) MatchesTypes(code string, include, exclude []string) bool {
+	return !d.isAnyType(code, exclude) && d.isAnyType(code, include)
+}
+
+// isAnyType reports whether an item type is, or descends from, one of types
+func (d *Data) isAnyType(code string, types []string) bool {
+	seen := make(map[string]bool)
+	pending := []string{code}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if current == "" || seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		for _, t := range types {
+			if strings.EqualFold(t, current) {
+				return true
+			}
+		}
+		pending = append(pending, d.typeParents[current]...)
+	}
+	return false
+}
+
+// MagicPrefix returns the magic prefix with the given ID
+func (d *Data) MagicPrefix(id int) (Affix, bool) {
+	affix, ok := d.magicPrefixes[id]
+	return affix, ok
+}
+
+// MagicSuffix returns the magic suffix with the given ID
+func (d *Data) MagicSuffix(id int) (Affix, bool) {
+	affix, ok := d.magicSuffixes[id]
+	return affix, ok
+}
+
+// Stat returns the ItemStatCost row for a stat ID
+func (d *Data) Stat(id int) (StatCost, bool) {
+	s, ok := d.stats[id]
+	return s, ok
+}
+
+// Stats returns every ItemStatCost row, ordered by ID
+func (d *Data) Stats() []StatCost {
+	all := make([]StatCost, 0, len(d.stats))
+	for _, s := range d.stats {
+		all = append(all, s)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all
+}
+
+// StatByName returns the ItemStatCost row for a stat key, e.g. "item_fastercastrate"
+func (d *Data) StatByName(name string) (StatCost, bool) {
+	id, ok := d.statsByName[strings.ToLower(name)]
+	if !ok {
+		return StatCost{}, false
+	}
+	return d.Stat(id)
+}
+
+// functionStats are the stats of properties whose Properties.txt row leaves
+// them to the property's function, like enhanced damage
+var functionStats = map[string][]string{
+	"dmg%":    {"item_maxdamage_percent", "item_mindamage_percent"},
+	"dmg-min": {"mindamage"},
+	"dmg-max": {"maxdamage"},
+}
+
+// PropertyStats returns the IDs of the stats a Properties.txt code sets, e.g.
+// the four resistances for "res-all". Properties setting several stats roll
+// one value for all of them.
+func (d *Data) PropertyStats(code string) []int {
+	var ids []int
+	for _, ps := range d.propertyStats(code) {
+		if sc, ok := d.StatByName(ps.stat); ok && !rangeFuncs[ps.fn] {
+			ids = append(ids, sc.ID)
+		}
+	}
+	return ids
+}
+
+// ModStat is a stat set to a fixed value, as items store it
+type ModStat struct {
+	ID    int
+	Layer int
+	Value int
+}
+
+// FixedStats returns the stats a property with a fixed value sets, like a
+// gem's bonus. Damage ranges set their stats from the min, max and param.
+func (d *Data) FixedStats(mod Mod) []ModStat {
+	var stats []ModStat
+	for _, ps := range d.propertyStats(mod.Property) {
+		sc, ok := d.StatByName(ps.stat)
+		if !ok {
+			continue
+		}
+
+		value := mod.Min
+		switch ps.fn {
+		case 16:
+			value = mod.Max
+		case 17:
+			value, _ = strconv.Atoi(mod.Param)
+		}
+		stats = append(stats, ModStat{ID: sc.ID, Value: value})
+	}
+	return stats
+}
+
+// propertyStats returns the stats a property sets, falling back to
+// functionStats for properties whose row leaves them to the function
+func (d *Data) propertyStats(code string) []propertyStat {
+	if stats := d.properties[code]; len(stats) > 0 {
+		return stats
+	}
+
+	var stats []propertyStat
+	for _, name := range functionStats[code] {
+		stats = append(stats, propertyStat{stat: name})
+	}
+	return stats
+}
+
+// Gem returns the gem or rune with the given item code
+func (d *Data) Gem(code string) (Gem, bool) {
+	gem, ok := d.gems[code]
+	return gem, ok
+}
+
+// MagicPrefixName returns a magic prefix's name, or "" when unknown
+func (d *Data) MagicPrefixName(id int) string {
+	return d.magicPrefixes[id].Name
+}
+
+// MagicSuffixName returns a magic suffix's name, or "" when unknown
+func (d *Data) MagicSuffixName(id int) string {
+	return d.magicSuffixes[id].Name
+}
+
+// RarePrefixName returns a rare name's first word, or "" when unknown
+func (d *Data) RarePrefixName(id int) string {
+	return d.rareName(id)
+}
+
+// RareSuffixName returns a rare name's second word, or "" when unknown
+func (d *Data) RareSuffixName(id int) string {
+	return d.rareName(id)
+}
+
+// rareName resolves a word of a rare name. The game numbers both words in one
+// list starting at 1: the rows of RareSuffix.txt, then those of RarePrefix.txt.
+func (d *Data) rareName(id int) string {
+	if id < 1 {
+		return ""
+	}
+	if id <= len(d.rareSuffixes) {
+		return d.rareSuffixes[id-1].Name
+	}
+	id -= len(d.rareSuffixes)
+	if id <= len(d.rarePrefixes) {
+		return d.rarePrefixes[id-1].Name
+	}
+	return ""
+}
+
+// String resolves a string key from the description columns to its English
+// text. Keys without a string are returned as is.
+func (d *Data) String(key string) string {
+	text, ok := d.strings[key]
+	if !ok {
+		return key
+	}
+
+	// Drop color codes ("ÿc5") that some strings start with
+	for strings.HasPrefix(text, "ÿc") && len(text) >= len("ÿc")+1 {
+		text = text[len("ÿc")+1:]
+	}
+	return text
+}
+
+// HasString reports whether a string key resolves to text
+func (d *Data) HasString(key string) bool {
+	_, ok := d.strings[key]
+	return ok
+}
+
+// GroupStats returns the stats that share a description group, ordered by ID
+func (d *Data) GroupStats(group int) []StatCost {
+	var members []StatCost
+	for _, s := range d.stats {
+		if s.DescGroup == group {
+			members = append(members, s)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members
+}
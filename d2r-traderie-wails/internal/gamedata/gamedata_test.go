@@ -0,0 +1,402 @@
+package gamedata
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+)
+
+// writeTable writes a tab-separated table to dir
+func writeTable(t *testing.T, dir, file, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadReportsMissingTables(t *testing.T) {
+	dir := t.TempDir()
+	writeTable(t, dir, "UniqueItems.txt", "index\t*ID\tcode\n"+
+		"The Gnasher\t0\thax\n"+
+		"Expansion\t\t\n"+
+		"Harlequin Crest\t224\tuap\n")
+
+	data, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if !data.Extracted(FileUniqueItems) {
+		t.Errorf("%s not reported as extracted", FileUniqueItems)
+	}
+	if got := data.Source(FileArmor); got != SourceEmbedded {
+		t.Errorf("Source(%s) = %q, want %q", FileArmor, got, SourceEmbedded)
+	}
+
+	// The tables that weren't extracted come from the embedded copy
+	if got := data.Missing(); len(got) != 0 {
+		t.Errorf("Missing() = %v, want none", got)
+	}
+}
+
+func TestLoadEmbeddedTables(t *testing.T) {
+	data, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := data.Missing(); len(got) != 0 {
+		t.Errorf("Missing() = %v, want none", got)
+	}
+	if got := data.Source(FileItemModifiers); got != SourceEmbedded {
+		t.Errorf("Source(%s) = %q, want %q", FileItemModifiers, got, SourceEmbedded)
+	}
+
+	if sc, ok := data.StatByName("armorclass"); !ok || sc.ID != 31 || sc.SaveBits == 0 {
+		t.Errorf("StatByName(armorclass) = %+v, %v, want stat 31 with save bits", sc, ok)
+	}
+	if got := data.PropertyStats("res-all"); !reflect.DeepEqual(got, []int{39, 41, 43, 45}) {
+		t.Errorf("PropertyStats(res-all) = %v, want the four resistances", got)
+	}
+	if rw, ok := data.RunewordByRunes([]string{"r07", "r10", "r09", "r11"}); !ok || rw.Name != "Spirit" {
+		t.Errorf("RunewordByRunes(Tal Thul Ort Amn) = %q, %v, want Spirit", rw.Name, ok)
+	}
+
+	// Every description string the stats refer to is there
+	for _, sc := range data.Stats() {
+		for _, key := range []string{sc.DescStrPos, sc.DescStrNeg, sc.DescStr2, sc.DescGroupStrPos} {
+			if key != "" && data.String(key) == key {
+				t.Errorf("stat %d (%s) refers to string %q, which isn't loaded", sc.ID, sc.Stat, key)
+			}
+		}
+	}
+}
+
+func TestLoadUsesIDColumn(t *testing.T) {
+	dir := t.TempDir()
+	writeTable(t, dir, FileUniqueItems, "index\t*ID\tcode\n"+
+		"The Gnasher\t0\thax\n"+
+		"Expansion\t\t\n"+
+		"Harlequin Crest\t224\tuap\n")
+
+	data, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	unique, ok := data.UniqueByID(224)
+	if !ok || unique.Name != "Harlequin Crest" {
+		t.Errorf("UniqueByID(224) = %q, %v, want Harlequin Crest", unique.Name, ok)
+	}
+	if _, ok := data.UniqueByID(2); ok {
+		t.Error("UniqueByID fell back to the row position despite an *ID column")
+	}
+}
+
+func TestLoadWithoutExtractedTables(t *testing.T) {
+	data, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, file := range Tables {
+		if data.Extracted(file) {
+			t.Errorf("%s reported as extracted from an empty folder", file)
+		}
+	}
+
+	// The embedded tables name bases and uniques on their own
+	if base, ok := data.BaseByName("Shako"); !ok || base.Code != "uap" {
+		t.Errorf("BaseByName(Shako) = %+v, %v, want the embedded uap", base, ok)
+	}
+	if _, ok := data.Unique("Harlequin Crest"); !ok {
+		t.Error("Unique(Harlequin Crest) not found in the embedded tables")
+	}
+}
+
+func TestUniquesSharingAName(t *testing.T) {
+	data, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got := len(data.Uniques("Rainbow Facet")); got != 8 {
+		t.Errorf("len(Uniques(Rainbow Facet)) = %d, want 8", got)
+	}
+	azurewrath := data.Uniques("azurewrath")
+	if len(azurewrath) != 2 || azurewrath[0].Code != "crs" || azurewrath[1].Code != "7cr" {
+		t.Errorf("Uniques(azurewrath) = %+v, want the crs and 7cr rows", azurewrath)
+	}
+	if u, ok := data.Unique("Azurewrath"); !ok || u.ID != azurewrath[0].ID {
+		t.Errorf("Unique(Azurewrath) = %+v, %v, want the first row", u, ok)
+	}
+}
+
+// handMadeColumns lists the columns each hand-transcribed table may carry:
+// only the ones the loader reads, so the tables stay an explicit subset of
+// the game's
+var handMadeColumns = map[string][]string{
+	FileRunes: append(append([]string{"Name", "*Rune Name", "complete"},
+		numbered("itype%d", 6, "etype%d", 3, "Rune%d", 6)...),
+		numbered("T1Code%d", 7, "T1Param%d", 7, "T1Min%d", 7, "T1Max%d", 7)...),
+	FileItemStatCost: {"Stat", "*ID", "Signed", "Save Bits", "Save Add", "Save Param Bits", "op", "op param",
+		"descpriority", "descfunc", "descval", "descstrpos", "descstrneg", "descstr2",
+		"dgrp", "dgrpfunc", "dgrpval", "dgrpstrpos", "dgrpstrneg", "dgrpstr2"},
+	FileProperties: append([]string{"code"}, numbered("func%d", 7, "stat%d", 7)...),
+	FileGems: append([]string{"name", "code"}, numbered(
+		"weaponMod%dCode", 3, "weaponMod%dParam", 3, "weaponMod%dMin", 3, "weaponMod%dMax", 3,
+		"helmMod%dCode", 3, "helmMod%dParam", 3, "helmMod%dMin", 3, "helmMod%dMax", 3,
+		"shieldMod%dCode", 3, "shieldMod%dParam", 3, "shieldMod%dMin", 3, "shieldMod%dMax", 3)...),
+}
+
+// numbered expands pairs of a column format and a count, e.g. "Rune%d", 6
+func numbered(formats ...any) []string {
+	var columns []string
+	for i := 0; i < len(formats); i += 2 {
+		for n := 1; n <= formats[i+1].(int); n++ {
+			columns = append(columns, fmt.Sprintf(formats[i].(string), n))
+		}
+	}
+	return columns
+}
+
+func TestHandMadeTables(t *testing.T) {
+	for file, columns := range handMadeColumns {
+		table, source, err := openTable("", file)
+		if err != nil || source != SourceEmbedded {
+			t.Fatalf("openTable(%s) = %q, %v, want the embedded copy", file, source, err)
+		}
+		allowed := map[string]bool{}
+		for _, column := range columns {
+			allowed[strings.ToLower(column)] = true
+		}
+		for column := range table.columns {
+			if !allowed[column] {
+				t.Errorf("%s has column %q, which the loader doesn't read", file, column)
+			}
+		}
+	}
+
+	data, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// ItemStatCost has every catalog stat
+	catalog, err := stats.Load("")
+	if err != nil {
+		t.Fatalf("stats.Load: %v", err)
+	}
+	for _, entry := range catalog.Entries() {
+		if sc, ok := data.Stat(entry.ID); !ok || sc.Stat != entry.Key {
+			t.Errorf("stat %d (%s) is missing from %s", entry.ID, entry.Key, FileItemStatCost)
+		}
+	}
+
+	// The strings are only the ones the stat descriptions refer to
+	used := map[string]bool{}
+	for _, sc := range data.Stats() {
+		for _, key := range []string{sc.DescStrPos, sc.DescStrNeg, sc.DescStr2, sc.DescGroupStrPos, sc.DescGroupStrNeg, sc.DescGroupStr2} {
+			used[key] = true
+		}
+	}
+	for key := range data.strings {
+		if !used[key] {
+			t.Errorf("%s has string %q, which no stat refers to", FileItemModifiers, key)
+		}
+	}
+
+	// Properties covers every property the other tables roll, and names
+	// stats ItemStatCost has, with the width saves store them in
+	var mods []Mod
+	for _, u := range data.uniques {
+		mods = append(mods, u.Mods...)
+	}
+	for _, s := range data.setItems {
+		mods = append(mods, s.Mods...)
+	}
+	for _, rw := range data.runewords {
+		mods = append(mods, rw.Mods...)
+	}
+	for _, gem := range data.gems {
+		mods = append(append(append(mods, gem.Weapon...), gem.Helm...), gem.Shield...)
+	}
+	for _, affixes := range []map[int]Affix{data.magicPrefixes, data.magicSuffixes} {
+		for _, a := range affixes {
+			mods = append(mods, a.Mods...)
+		}
+	}
+	for _, mod := range mods {
+		if _, ok := data.properties[mod.Property]; !ok {
+			t.Errorf("property %q is missing from %s", mod.Property, FileProperties)
+		}
+	}
+	for code, stats := range data.properties {
+		for _, ps := range stats {
+			if sc, ok := data.StatByName(ps.stat); !ok {
+				t.Errorf("property %q sets stat %q, which is missing from %s", code, ps.stat, FileItemStatCost)
+			} else if sc.SaveBits == 0 {
+				t.Errorf("property %q sets stat %q, which has no save bits", code, ps.stat)
+			}
+		}
+	}
+
+	// Gems has a row for every gem and rune Misc has, and nothing else
+	for _, base := range data.Bases() {
+		isGem := base.Type == "rune" || data.MatchesTypes(base.Type, []string{"gem"}, nil)
+		if _, ok := data.Gem(base.Code); ok != isGem {
+			t.Errorf("%s (%s) has a %s row: %v, want %v", base.Name, base.Code, FileGems, ok, isGem)
+		}
+	}
+
+	// Runes only spell complete runewords from runes Misc has
+	for _, rw := range data.runewords {
+		if !rw.Complete || len(rw.Runes) == 0 {
+			t.Errorf("runeword %q isn't a complete recipe", rw.Name)
+		}
+		for _, code := range rw.Runes {
+			if base, ok := data.Base(code); !ok || base.Type != "rune" {
+				t.Errorf("runeword %q uses %q, which isn't a rune in %s", rw.Name, code, FileMisc)
+			}
+		}
+	}
+}
+
+func TestRareNames(t *testing.T) {
+	data, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// One list numbered from 1: the 155 suffixes, then the 46 prefixes
+	tests := []struct {
+		id   int
+		want string
+	}{
+		{0, ""},
+		{1, "Bite"},
+		{155, "Flange"},
+		{156, "Beast"},
+		{201, "Corruption"},
+		{202, ""},
+	}
+
+	for _, tt := range tests {
+		if got := data.RarePrefixName(tt.id); got != tt.want {
+			t.Errorf("RarePrefixName(%d) = %q, want %q", tt.id, got, tt.want)
+		}
+		if got := data.RareSuffixName(tt.id); got != tt.want {
+			t.Errorf("RareSuffixName(%d) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestMatchesTypes(t *testing.T) {
+	dir := t.TempDir()
+	writeTable(t, dir, FileItemTypes, "ItemType\tCode\tEquiv1\tEquiv2\n"+
+		"Any Armor\tarmo\t\t\n"+
+		"Weapon\tweap\t\t\n"+
+		"Melee Weapon\tmele\tweap\t\n"+
+		"Sword\tswor\tmele\t\n"+
+		"Helm\thelm\tarmo\t\n"+
+		"Primal Helm\tphlm\thelm\tclas\n")
+
+	data, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		code    string
+		include []string
+		exclude []string
+		want    bool
+	}{
+		{"swor", []string{"swor"}, nil, true},
+		{"swor", []string{"weap"}, nil, true},
+		{"phlm", []string{"armo"}, nil, true},
+		{"phlm", []string{"weap"}, nil, false},
+		{"phlm", []string{"helm"}, []string{"clas"}, false},
+		{"swor", []string{"weap"}, []string{"mele"}, false},
+		{"swor", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := data.MatchesTypes(tt.code, tt.include, tt.exclude); got != tt.want {
+			t.Errorf("MatchesTypes(%q, %v, %v) = %v, want %v", tt.code, tt.include, tt.exclude, got, tt.want)
+		}
+	}
+}
+
+func TestPropertyStats(t *testing.T) {
+	dir := t.TempDir()
+	writeTable(t, dir, FileItemStatCost, "Stat\t*ID\n"+
+		"item_maxdamage_percent\t17\n"+
+		"item_mindamage_percent\t18\n"+
+		"fireresist\t39\n"+
+		"lightresist\t41\n")
+	writeTable(t, dir, FileProperties, "code\tfunc1\tstat1\tfunc2\tstat2\n"+
+		"res-fire\t1\tfireresist\t\t\n"+
+		"res-fl\t1\tfireresist\t1\tlightresist\n"+
+		"dmg%\t7\t\t\t\n"+
+		"dmg-range\t15\tfireresist\t16\tlightresist\n")
+
+	data, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		code string
+		want []int
+	}{
+		{"res-fire", []int{39}},
+		{"res-fl", []int{39, 41}},
+		{"dmg%", []int{17, 18}},
+		{"dmg-range", nil},
+		{"nope", nil},
+	}
+
+	for _, tt := range tests {
+		if got := data.PropertyStats(tt.code); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PropertyStats(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestFixedStats(t *testing.T) {
+	data, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		code string
+		slot func(Gem) []Mod
+		want []ModStat
+	}{
+		// Cold damage sets its length from the param
+		{"r10", func(g Gem) []Mod { return g.Weapon }, []ModStat{{ID: 54, Value: 3}, {ID: 55, Value: 14}, {ID: 56, Value: 75}}},
+		// Enhanced damage has no stats in its Properties row
+		{"r27", func(g Gem) []Mod { return g.Weapon }, []ModStat{{ID: 17, Value: 50}, {ID: 18, Value: 50}}},
+		{"r22", func(g Gem) []Mod { return g.Shield }, []ModStat{{ID: 39, Value: 22}, {ID: 41, Value: 22}, {ID: 43, Value: 22}, {ID: 45, Value: 22}}},
+		{"skz", func(g Gem) []Mod { return g.Helm }, []ModStat{{ID: 74, Value: 5}, {ID: 27, Value: 19}}},
+	}
+
+	for _, tt := range tests {
+		gem, ok := data.Gem(tt.code)
+		if !ok {
+			t.Fatalf("Gem(%q) not found", tt.code)
+		}
+		var got []ModStat
+		for _, mod := range tt.slot(gem) {
+			got = append(got, data.FixedStats(mod)...)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FixedStats of %s = %+v, want %+v", gem.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package gamedata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// table is a parsed tab-separated excel file
+type table struct {
+	columns map[string]int
+	rows    []row
+}
+
+// row is one line of a table, read through its table's column names
+type row struct {
+	t      *table
+	fields []string
+}
+
+// parseTable reads a D2R excel .txt file: a header line followed by one
+// tab-separated record per line
+func parseTable(r io.Reader) (*table, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("missing header line")
+	}
+
+	t := &table{columns: make(map[string]int)}
+	header := strings.TrimPrefix(scanner.Text(), "\ufeff")
+	for i, name := range strings.Split(strings.TrimRight(header, "\r"), "\t") {
+		// Keep the first of any duplicated column names
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, exists := t.columns[key]; !exists {
+			t.columns[key] = i
+		}
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		t.rows = append(t.rows, row{t: t, fields: strings.Split(line, "\t")})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// has reports whether the table has a column
+func (t *table) has(column string) bool {
+	_, ok := t.columns[strings.ToLower(column)]
+	return ok
+}
+
+// str returns a column's trimmed value, or "" when the column is missing
+func (r row) str(column string) string {
+	i, ok := r.t.columns[strings.ToLower(column)]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// int returns a column's value as an integer, or 0 when it is empty or not a number
+func (r row) int(column string) int {
+	n, _ := strconv.Atoi(r.str(column))
+	return n
+}
+
+// bool returns whether a column holds a non-zero number
+func (r row) bool(column string) bool {
+	return r.int(column) != 0
+}
+
+// list collects the non-empty values of numbered columns, e.g. itype1..itype6
+func (r row) list(format string, count int) []string {
+	var values []string
+	for i := 1; i <= count; i++ {
+		if v := r.str(fmt.Sprintf(format, i)); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// separator reports whether the row only divides sections, like the
+// "Expansion" lines in the item tables
+func (r row) separator(nameColumn string) bool {
+	name := r.str(nameColumn)
+	return name == "" || strings.EqualFold(name, "Expansion")
+}
+
+// id returns the row's "*ID" column when the table has one, otherwise its position
+func (r row) id(position int) int {
+	for _, column := range []string{"*ID", "ID"} {
+		if r.t.has(column) {
+			if v := r.str(column); v != "" {
+				if n, err := strconv.Atoi(v); err == nil {
+					return n
+				}
+			}
+		}
+	}
+	return position
+}
@@ -3,6 +3,7 @@ package hotkey
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/moutend/go-hook/pkg/keyboard"
 	"github.com/moutend/go-hook/pkg/types"
@@ -14,16 +15,41 @@ type Listener struct {
 	callback func()
 	stopChan chan struct{}
 	keyCode  types.VKCode
+	extra    map[types.VKCode]func() // Additional bindings, e.g. per-instance hotkeys
 }
 
 // NewListener creates a new hotkey listener
-func NewListener(hotkey string, callback func()) *Listener {
+func NewListener(hotkey string, callback func()) (*Listener, error) {
+	keyCode, err := parseHotkey(hotkey)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Listener{
 		hotkey:   hotkey,
 		callback: callback,
 		stopChan: make(chan struct{}),
-		keyCode:  parseHotkey(hotkey),
+		keyCode:  keyCode,
+		extra:    make(map[types.VKCode]func()),
+	}, nil
+}
+
+// Bind registers an additional hotkey. Must be called before Start.
+func (l *Listener) Bind(hotkey string, callback func()) error {
+	keyCode, err := parseHotkey(hotkey)
+	if err != nil {
+		return err
 	}
+	if keyCode == l.keyCode {
+		return fmt.Errorf("hotkey %s is already bound to item capture", hotkey)
+	}
+	if _, ok := l.extra[keyCode]; ok {
+		return fmt.Errorf("hotkey %s is bound twice", hotkey)
+	}
+
+	l.extra[keyCode] = callback
+	log.Printf("Bound additional hotkey: %s", hotkey)
+	return nil
 }
 
 // Start begins listening for hotkey presses
@@ -56,6 +82,9 @@ func (l *Listener) listen(keyboardChan chan types.KeyboardEvent) {
 				if event.VKCode == l.keyCode {
 					log.Println("Hotkey detected!")
 					l.callback()
+				} else if callback, ok := l.extra[event.VKCode]; ok {
+					log.Println("Additional hotkey detected!")
+					callback()
 				}
 			}
 		}
@@ -71,7 +100,7 @@ func (l *Listener) Stop() {
 }
 
 // parseHotkey converts hotkey string to VK code
-func parseHotkey(hotkey string) types.VKCode {
+func parseHotkey(hotkey string) (types.VKCode, error) {
 	// Map common hotkey names to VK codes
 	hotkeyMap := map[string]types.VKCode{
 		"F1":  types.VK_F1,
@@ -88,11 +117,10 @@ func parseHotkey(hotkey string) types.VKCode {
 		"F12": types.VK_F12,
 	}
 
-	if vkCode, ok := hotkeyMap[hotkey]; ok {
-		return vkCode
+	if vkCode, ok := hotkeyMap[strings.ToUpper(strings.TrimSpace(hotkey))]; ok {
+		return vkCode, nil
 	}
 
-	// Default to F9
-	return types.VK_F9
+	return 0, fmt.Errorf("unknown hotkey %q, expected F1-F12", hotkey)
 }
 
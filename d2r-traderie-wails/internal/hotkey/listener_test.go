@@ -0,0 +1,64 @@
+package hotkey
+
+import (
+	"testing"
+
+	"github.com/moutend/go-hook/pkg/types"
+)
+
+func TestParseHotkey(t *testing.T) {
+	tests := []struct {
+		hotkey  string
+		want    types.VKCode
+		wantErr bool
+	}{
+		{hotkey: "F9", want: types.VK_F9},
+		{hotkey: "F12", want: types.VK_F12},
+		{hotkey: " f10 ", want: types.VK_F10},
+		{hotkey: "F13", wantErr: true},
+		{hotkey: "Ctrl+Shift+P", wantErr: true},
+		{hotkey: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseHotkey(tt.hotkey)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseHotkey(%q) = %v, want an error", tt.hotkey, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseHotkey(%q) = %v, %v, want %v", tt.hotkey, got, err, tt.want)
+		}
+	}
+}
+
+func TestNewListenerRejectsUnknownHotkey(t *testing.T) {
+	if _, err := NewListener("Insert", func() {}); err == nil {
+		t.Error("NewListener accepted an unknown hotkey")
+	}
+}
+
+func TestBind(t *testing.T) {
+	l, err := NewListener("F9", func() {})
+	if err != nil {
+		t.Fatalf("NewListener: %v", err)
+	}
+
+	if err := l.Bind("F10", func() {}); err != nil {
+		t.Errorf("Bind(F10): %v", err)
+	}
+	if err := l.Bind("F9", func() {}); err == nil {
+		t.Error("Bind accepted the capture hotkey")
+	}
+	if err := l.Bind("F10", func() {}); err == nil {
+		t.Error("Bind accepted a hotkey bound twice")
+	}
+	if err := l.Bind("PageUp", func() {}); err == nil {
+		t.Error("Bind accepted an unknown hotkey")
+	}
+	if len(l.extra) != 1 {
+		t.Errorf("%d extra hotkeys bound, want 1", len(l.extra))
+	}
+}
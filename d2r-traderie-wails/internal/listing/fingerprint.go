@@ -0,0 +1,78 @@
+package listing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// volatileStats change as an item is used, so they're left out of its fingerprint
+var volatileStats = map[string]bool{
+	"quantity":   true,
+	"durability": true,
+}
+
+// Fingerprint identifies a scanned item from its unit ID, base code,
+// quality, stats and sockets. Items read from a save without an ID, like
+// runes and gems, use their place in the save instead, so two identical
+// runes don't share one. Stats that wear down (durability, quantity,
+// remaining charges) are left out and the rest sorted, so rescanning the
+// same item gets the same fingerprint.
+func Fingerprint(item *models.Item) string {
+	if item == nil {
+		return ""
+	}
+
+	base := item.Code
+	if base == "" {
+		// Items imported from text have no code when the base isn't in the tables
+		base = strings.ToLower(item.BaseName)
+	}
+
+	statKeys := make([]string, 0, len(item.Stats))
+	for _, s := range item.Stats {
+		value := s.Value
+		if entry, ok := stats.Default().Lookup(s.ID); ok {
+			if volatileStats[entry.Key] {
+				continue
+			}
+			if entry.Encoding == stats.EncodingCharges {
+				value >>= 8 // Keep the max charges, drop the remaining ones
+			}
+		}
+		statKeys = append(statKeys, fmt.Sprintf("%d:%d:%d", s.ID, s.Layer, value))
+	}
+	sort.Strings(statKeys)
+
+	socketed := make([]string, 0, len(item.SocketedItems))
+	for _, s := range item.SocketedItems {
+		socketed = append(socketed, s.Name)
+	}
+
+	key := strings.Join([]string{
+		identity(item),
+		base,
+		item.Quality,
+		strings.Join(statKeys, ","),
+		fmt.Sprint(item.Sockets),
+		strings.Join(socketed, ","),
+	}, "|")
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// identity tells apart items that are otherwise identical: the unit ID, or
+// where the item sits in its save when it has none
+func identity(item *models.Item) string {
+	if item.UnitID != 0 || item.Location == nil {
+		return fmt.Sprint(item.UnitID)
+	}
+	l := item.Location
+	return fmt.Sprintf("%s:%d:%s:%d:%d", l.Type, l.Tab, l.Slot, l.X, l.Y)
+}
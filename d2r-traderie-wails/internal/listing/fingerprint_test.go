@@ -0,0 +1,124 @@
+package listing
+
+import (
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// scannedShako is a Harlequin Crest as one scan reads it
+func scannedShako() *models.Item {
+	return &models.Item{
+		Name:    "Harlequin Crest",
+		Code:    "uap",
+		UnitID:  4211,
+		Quality: "Unique",
+		Stats: []models.Stat{
+			{ID: 16, Value: 141},                          // Enhanced defense
+			{ID: 72, Value: 12},                           // Durability
+			{ID: 73, Value: 12},                           // Max durability
+			{ID: 80, Value: 50},                           // Magic find
+			{ID: 127, Value: 2},                           // All skills
+			{ID: 204, Layer: 54<<6 | 3, Value: 10<<8 | 7}, // Level 3 Teleport, 7 of 10 charges
+		},
+		Sockets: 1,
+		SocketedItems: []models.SocketedItem{
+			{Name: "Um Rune", Type: "rune"},
+		},
+	}
+}
+
+func TestFingerprintRescanCollides(t *testing.T) {
+	first := scannedShako()
+
+	// The same item scanned again: stats listed in another order, some
+	// durability lost and a charge used
+	rescan := scannedShako()
+	rescan.Stats = []models.Stat{
+		{ID: 204, Layer: 54<<6 | 3, Value: 10<<8 | 6},
+		{ID: 127, Value: 2},
+		{ID: 80, Value: 50},
+		{ID: 73, Value: 12},
+		{ID: 72, Value: 9},
+		{ID: 16, Value: 141},
+	}
+
+	if Fingerprint(first) != Fingerprint(rescan) {
+		t.Errorf("rescan of the same item got a different fingerprint")
+	}
+}
+
+func TestFingerprintDistinguishesItems(t *testing.T) {
+	base := Fingerprint(scannedShako())
+
+	tests := []struct {
+		name   string
+		modify func(*models.Item)
+	}{
+		{"different roll", func(it *models.Item) { it.Stats[0].Value = 135 }},
+		{"different max charges", func(it *models.Item) { it.Stats[5].Value = 12<<8 | 7 }},
+		{"different max durability", func(it *models.Item) { it.Stats[2].Value = 24 }},
+		{"different socketed rune", func(it *models.Item) { it.SocketedItems[0].Name = "Ist Rune" }},
+		{"different quality", func(it *models.Item) { it.Quality = "Set" }},
+		{"different base", func(it *models.Item) { it.Code = "xap" }},
+		{"different unit ID", func(it *models.Item) { it.UnitID = 91 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := scannedShako()
+			tt.modify(item)
+			if Fingerprint(item) == base {
+				t.Errorf("%s: fingerprint collides with the original item", tt.name)
+			}
+		})
+	}
+}
+
+func TestFingerprintIdenticalRunes(t *testing.T) {
+	ber := func(unitID int, location *models.ItemLocation) *models.Item {
+		return &models.Item{Name: "Ber Rune", Code: "r30", UnitID: unitID, Quality: "Normal", Location: location}
+	}
+
+	tests := []struct {
+		name  string
+		a, b  *models.Item
+		equal bool
+	}{
+		{
+			name: "different unit IDs",
+			a:    ber(17, nil),
+			b:    ber(18, nil),
+		},
+		{
+			name: "saved without IDs at different places",
+			a:    ber(0, &models.ItemLocation{Type: "shared_stash", Tab: 1, X: 0, Y: 0}),
+			b:    ber(0, &models.ItemLocation{Type: "shared_stash", Tab: 1, X: 1, Y: 0}),
+		},
+		{
+			name: "saved without IDs on different tabs",
+			a:    ber(0, &models.ItemLocation{Type: "shared_stash", Tab: 1}),
+			b:    ber(0, &models.ItemLocation{Type: "shared_stash", Tab: 2}),
+		},
+		{
+			name:  "the same rune scanned twice",
+			a:     ber(17, &models.ItemLocation{Type: "stash"}),
+			b:     ber(17, &models.ItemLocation{Type: "stash"}),
+			equal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fingerprint(tt.a) == Fingerprint(tt.b); got != tt.equal {
+				t.Errorf("fingerprints equal = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestFingerprintNil(t *testing.T) {
+	if got := Fingerprint(nil); got != "" {
+		t.Errorf("Fingerprint(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,117 @@
+package listing
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ActiveFor is how long a posted listing is treated as still up on Traderie
+const ActiveFor = 7 * 24 * time.Hour
+
+// Posting records one item posted to Traderie
+type Posting struct {
+	Fingerprint string    `json:"fingerprint"`
+	Item        string    `json:"item"`
+	Platform    string    `json:"platform"`
+	Mode        string    `json:"mode"`
+	Ladder      bool      `json:"ladder"`
+	PostedAt    time.Time `json:"posted_at"`
+}
+
+// History remembers which items were posted, so the same item isn't listed twice
+type History struct {
+	postings map[string]Posting // Key: fingerprint, latest posting only
+	mu       sync.RWMutex
+	filePath string
+}
+
+// NewHistory creates a posting history stored in dir
+func NewHistory(dir string) *History {
+	h := &History{
+		postings: make(map[string]Posting),
+		filePath: filepath.Join(dir, "listings.json"),
+	}
+
+	if err := h.Load(); err != nil {
+		log.Printf("⚠️ Failed to load listing history: %v", err)
+	}
+	return h
+}
+
+// Load loads the posting history from disk
+func (h *History) Load() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	data, err := os.ReadFile(h.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read listing history: %w", err)
+	}
+
+	var postings []Posting
+	if err := json.Unmarshal(data, &postings); err != nil {
+		return fmt.Errorf("failed to parse listing history: %w", err)
+	}
+	for _, p := range postings {
+		h.postings[p.Fingerprint] = p
+	}
+
+	log.Printf("✓ Loaded %d posted listings", len(h.postings))
+	return nil
+}
+
+// Save writes the posting history to disk, dropping postings that are no longer active
+func (h *History) Save() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	postings := make([]Posting, 0, len(h.postings))
+	for fingerprint, p := range h.postings {
+		if time.Since(p.PostedAt) > ActiveFor {
+			delete(h.postings, fingerprint)
+			continue
+		}
+		postings = append(postings, p)
+	}
+
+	data, err := json.MarshalIndent(postings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal listing history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := os.WriteFile(h.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write listing history: %w", err)
+	}
+	return nil
+}
+
+// Record remembers a successful post and saves the history
+func (h *History) Record(p Posting) error {
+	h.mu.Lock()
+	h.postings[p.Fingerprint] = p
+	h.mu.Unlock()
+
+	return h.Save()
+}
+
+// Active returns the active listing posted for a fingerprint, if any
+func (h *History) Active(fingerprint string) (Posting, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	p, ok := h.postings[fingerprint]
+	if !ok || fingerprint == "" || time.Since(p.PostedAt) > ActiveFor {
+		return Posting{}, false
+	}
+	return p, true
+}
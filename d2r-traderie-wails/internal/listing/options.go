@@ -0,0 +1,85 @@
+package listing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// Traderie's game modes
+const (
+	ModeSoftcore = "softcore"
+	ModeHardcore = "hardcore"
+)
+
+// Options are the Traderie trading options that must match the game an item is in
+type Options struct {
+	Mode   string `json:"mode"` // softcore or hardcore
+	Ladder bool   `json:"ladder"`
+	Region string `json:"region"` // Americas, Europe or Asia
+}
+
+// Derived records which options came from the game rather than the config
+type Derived struct {
+	Mode   bool `json:"mode"`
+	Ladder bool `json:"ladder"`
+}
+
+// Defaults is the options an item should be listed with and where each came from
+type Defaults struct {
+	Options
+	Derived Derived `json:"derived"`
+	Source  string  `json:"source,omitempty"` // Character the options were derived from
+}
+
+// Derive returns the trading options implied by the character holding the
+// item. Options the game doesn't tell us keep the configured value; that
+// includes Region, since d2go doesn't expose the realm the game is connected to.
+func Derive(item *models.Item, configured Options) Defaults {
+	defaults := Defaults{Options: configured}
+	if defaults.Mode == "" {
+		defaults.Mode = ModeSoftcore
+	}
+
+	if item == nil || item.Character == nil {
+		return defaults
+	}
+	character := item.Character
+	defaults.Source = character.Name
+
+	if character.FlagsKnown {
+		defaults.Mode = ModeSoftcore
+		if character.Hardcore {
+			defaults.Mode = ModeHardcore
+		}
+		defaults.Ladder = character.Ladder
+		defaults.Derived.Mode = true
+		defaults.Derived.Ladder = true
+	}
+	return defaults
+}
+
+// Conflicts lists the chosen options that contradict the ones derived from
+// the game, e.g. a non-ladder item about to be listed as ladder
+func Conflicts(defaults Defaults, chosen Options) []string {
+	var conflicts []string
+
+	if defaults.Derived.Mode && !strings.EqualFold(chosen.Mode, defaults.Mode) {
+		conflicts = append(conflicts, fmt.Sprintf("%s is a %s character, but the item is being listed as %s",
+			defaults.Source, defaults.Mode, chosen.Mode))
+	}
+	if defaults.Derived.Ladder && chosen.Ladder != defaults.Ladder {
+		conflicts = append(conflicts, fmt.Sprintf("%s is a %s character, but the item is being listed as %s",
+			defaults.Source, ladderName(defaults.Ladder), ladderName(chosen.Ladder)))
+	}
+	return conflicts
+}
+
+// ladderName names a ladder flag the way Traderie does
+func ladderName(ladder bool) string {
+	if ladder {
+		return "Ladder"
+	}
+	return "Non-Ladder"
+}
@@ -0,0 +1,73 @@
+package listing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+func TestDerive(t *testing.T) {
+	configured := Options{Mode: ModeSoftcore, Ladder: false, Region: "Americas"}
+
+	tests := []struct {
+		name      string
+		character *models.Character
+		want      Defaults
+	}{
+		{
+			name: "no character keeps the config",
+			want: Defaults{Options: configured},
+		},
+		{
+			name:      "known flags set mode and ladder but keep the configured region",
+			character: &models.Character{Name: "Mulehaven", Ladder: true, FlagsKnown: true, Offline: true},
+			want: Defaults{
+				Options: Options{Mode: ModeSoftcore, Ladder: true, Region: "Americas"},
+				Derived: Derived{Mode: true, Ladder: true},
+				Source:  "Mulehaven",
+			},
+		},
+		{
+			name:      "an online character's flags override the configured mode",
+			character: &models.Character{Name: "Tradebot", Hardcore: true, Expansion: true, FlagsKnown: true},
+			want: Defaults{
+				Options: Options{Mode: ModeHardcore, Ladder: false, Region: "Americas"},
+				Derived: Derived{Mode: true, Ladder: true},
+				Source:  "Tradebot",
+			},
+		},
+		{
+			name:      "unknown flags keep the config",
+			character: &models.Character{Name: "Tradebot"},
+			want:      Defaults{Options: configured, Source: "Tradebot"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Derive(&models.Item{Name: "Harlequin Crest", Character: tt.character}, configured)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Derive() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	online := &models.Character{Name: "Tradebot", Ladder: true, FlagsKnown: true}
+	defaults := Derive(&models.Item{Character: online}, Options{Mode: ModeSoftcore, Region: "Americas"})
+
+	if got := Conflicts(defaults, defaults.Options); len(got) != 0 {
+		t.Errorf("derived options conflict with themselves: %q", got)
+	}
+
+	chosen := Options{Mode: ModeHardcore, Ladder: false, Region: "Asia"}
+	want := []string{
+		"Tradebot is a softcore character, but the item is being listed as hardcore",
+		"Tradebot is a Ladder character, but the item is being listed as Non-Ladder",
+	}
+	if got := Conflicts(defaults, chosen); !reflect.DeepEqual(got, want) {
+		t.Errorf("Conflicts() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package listing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// How serious a tradeability issue is
+const (
+	SeverityBlock = "block" // The item can't be traded; posting needs an explicit override
+	SeverityWarn  = "warn"  // Suspicious, but doesn't stop the post
+)
+
+// Issue is one reason an item may not be tradeable
+type Issue struct {
+	Code     string `json:"code"` // e.g. "quest_item", "offline_character"
+	Severity string `json:"severity"`
+	Reason   string `json:"reason"`
+}
+
+// Validate checks whether an item can be listed with the chosen options: its
+// base (quest items), the character holding it (single-player, classic), where
+// it was scanned, and trading options that contradict the character
+func Validate(item *models.Item, defaults Defaults, chosen Options) []Issue {
+	issues := []Issue{}
+	if item == nil {
+		return issues
+	}
+
+	if isQuestItem(item) {
+		issues = append(issues, Issue{
+			Code:     "quest_item",
+			Severity: SeverityBlock,
+			Reason:   fmt.Sprintf("%s is a quest item and can't be traded", item.Name),
+		})
+	}
+
+	if character := item.Character; character != nil {
+		switch {
+		case character.Offline:
+			issues = append(issues, Issue{
+				Code:     "offline_character",
+				Severity: SeverityBlock,
+				Reason:   fmt.Sprintf("%s is a single-player character; its items can't be traded online", character.Name),
+			})
+		case character.LocalSave:
+			// The game is online, so the save belongs to a single-player character that shares the name
+			issues = append(issues, Issue{
+				Code:     "local_save",
+				Severity: SeverityWarn,
+				Reason:   fmt.Sprintf("%s also has a single-player save on this PC; make sure the item is on the online character", character.Name),
+			})
+		}
+
+		if character.FlagsKnown && !character.Expansion {
+			issues = append(issues, Issue{
+				Code:     "classic_character",
+				Severity: SeverityBlock,
+				Reason:   fmt.Sprintf("%s is a classic character; Traderie only lists Lord of Destruction items", character.Name),
+			})
+		}
+	}
+
+	if item.Location != nil {
+		switch item.Location.Type {
+		case "ground":
+			issues = append(issues, Issue{
+				Code:     "on_ground",
+				Severity: SeverityWarn,
+				Reason:   "The item is lying on the ground, not held by the character",
+			})
+		case "equipped", "mercenary":
+			issues = append(issues, Issue{
+				Code:     "equipped",
+				Severity: SeverityWarn,
+				Reason:   "The item is equipped; unequip it before trading",
+			})
+		}
+	}
+
+	for _, conflict := range Conflicts(defaults, chosen) {
+		issues = append(issues, Issue{Code: "option_conflict", Severity: SeverityWarn, Reason: conflict})
+	}
+	return issues
+}
+
+// Blocking returns the issues that stop a post unless overridden
+func Blocking(issues []Issue) []Issue {
+	var blocking []Issue
+	for _, issue := range issues {
+		if issue.Severity == SeverityBlock {
+			blocking = append(blocking, issue)
+		}
+	}
+	return blocking
+}
+
+// Reasons joins the reasons of issues into one message
+func Reasons(issues []Issue) string {
+	reasons := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		reasons = append(reasons, issue.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// isQuestItem reports whether the item's base is flagged as a quest item in
+// the game's item tables
+func isQuestItem(item *models.Item) bool {
+	if strings.EqualFold(item.Type, "ques") {
+		return true
+	}
+
+	// Items imported from tooltip text may only have a base name or a name
+	data := gamedata.Default()
+	base, ok := data.Base(item.Code)
+	if !ok {
+		base, ok = data.BaseByName(item.BaseName)
+	}
+	if !ok {
+		base, ok = data.BaseByName(item.Name)
+	}
+	return ok && (base.Quest || base.Type == "ques")
+}
@@ -0,0 +1,122 @@
+package listing
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// withMiscTable installs game data holding only a misc.txt with the given rows
+func withMiscTable(t *testing.T, rows string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, gamedata.FileMisc), []byte("name\tcode\ttype\tquest\n"+rows), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := gamedata.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamedata.SetDefault(data)
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+}
+
+// issueCodes lists the codes and severities of issues
+func issueCodes(issues []Issue) []string {
+	var codes []string
+	for _, issue := range issues {
+		codes = append(codes, issue.Code+":"+issue.Severity)
+	}
+	return codes
+}
+
+func TestValidate(t *testing.T) {
+	withMiscTable(t, "Horadric Cube\tbox\tbox\t1\n"+
+		"Key of Terror\tpk1\tpk1\t0\n"+
+		"Horadric Scroll\ttr1\tques\t0\n")
+
+	online := &models.Character{Name: "Tradebot", Expansion: true, FlagsKnown: true}
+
+	tests := []struct {
+		name string
+		item *models.Item
+		want []string
+	}{
+		{
+			name: "tradeable item",
+			item: &models.Item{Name: "Key of Terror", Code: "pk1", Character: online},
+			want: nil,
+		},
+		{
+			name: "quest flag in misc.txt",
+			item: &models.Item{Name: "Horadric Cube", Code: "box", Character: online},
+			want: []string{"quest_item:block"},
+		},
+		{
+			name: "quest type",
+			item: &models.Item{Name: "Horadric Scroll", BaseName: "Horadric Scroll"},
+			want: []string{"quest_item:block"},
+		},
+		{
+			name: "tooltip import with only a name",
+			item: &models.Item{Name: "Horadric Cube"},
+			want: []string{"quest_item:block"},
+		},
+		{
+			name: "single-player save",
+			item: &models.Item{Name: "Key of Terror", Character: &models.Character{Name: "Mulehaven", Expansion: true, FlagsKnown: true, Offline: true}},
+			want: []string{"offline_character:block"},
+		},
+		{
+			name: "offline game with a local save",
+			item: &models.Item{Name: "Key of Terror", Character: &models.Character{Name: "Mulehaven", Expansion: true, FlagsKnown: true, Offline: true, LocalSave: true}},
+			want: []string{"offline_character:block"},
+		},
+		{
+			name: "online character with a local save",
+			item: &models.Item{Name: "Key of Terror", Character: &models.Character{Name: "Tradebot", Expansion: true, FlagsKnown: true, LocalSave: true}},
+			want: []string{"local_save:warn"},
+		},
+		{
+			name: "online classic character",
+			item: &models.Item{Name: "Key of Terror", Character: &models.Character{Name: "Tradebot", FlagsKnown: true}},
+			want: []string{"classic_character:block"},
+		},
+		{
+			name: "equipped on the mercenary",
+			item: &models.Item{Name: "Key of Terror", Location: &models.ItemLocation{Type: "mercenary"}},
+			want: []string{"equipped:warn"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := Derive(tt.item, Options{Mode: ModeSoftcore, Region: "Europe"})
+			got := issueCodes(Validate(tt.item, defaults, defaults.Options))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%s) = %q, want %q", tt.item.Name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOptionConflict(t *testing.T) {
+	withMiscTable(t, "")
+
+	item := &models.Item{Name: "Key of Terror", Character: &models.Character{Name: "Tradebot", Expansion: true, Ladder: true, FlagsKnown: true}}
+	defaults := Derive(item, Options{Mode: ModeSoftcore})
+
+	chosen := defaults.Options
+	chosen.Ladder = false
+	issues := Validate(item, defaults, chosen)
+	if got := issueCodes(issues); !reflect.DeepEqual(got, []string{"option_conflict:warn"}) {
+		t.Errorf("Validate() = %q, want a ladder conflict", got)
+	}
+	if len(Blocking(issues)) != 0 {
+		t.Error("an option conflict blocked the post")
+	}
+}
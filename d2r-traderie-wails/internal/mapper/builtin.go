@@ -0,0 +1,246 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// skillProcKeywords are the words a Traderie property uses for each skill proc trigger
+var skillProcKeywords = map[string][]string{
+	"":         {"charge"},
+	"attack":   {"attack"},
+	"striking": {"strik", "hit"},
+	"struck":   {"struck"},
+	"kill":     {"kill"},
+	"death":    {"death", "die"},
+	"level-up": {"level up", "gain a level"}, // "level" alone matches every "cast level N"
+}
+
+// MatchSkillProc picks the Traderie property for a charged or chance-to-cast
+// skill: the first candidate naming both the skill and the trigger.
+func MatchSkillProc(proc *models.SkillProc, candidates []string) string {
+	if proc == nil {
+		return ""
+	}
+
+	skillName := normalizeText(proc.Skill)
+	trigger := proc.Trigger
+	if proc.MaxCharges > 0 {
+		trigger = ""
+	}
+
+	for _, candidate := range candidates {
+		normalized := normalizeText(candidate)
+		if !strings.Contains(normalized, skillName) {
+			continue
+		}
+		for _, keyword := range skillProcKeywords[trigger] {
+			if strings.Contains(normalized, keyword) {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// newBuiltinTable returns the built-in table, filled in with every stat the
+// catalog knows a Traderie name for
+func newBuiltinTable() map[string]interface{} {
+	mappings := builtinMappings()
+	for name, traderieName := range stats.Default().TraderieNames() {
+		if _, ok := mappings[name]; !ok {
+			mappings[name] = traderieName
+		}
+	}
+	return mappings
+}
+
+// classMapping handles class-specific skill mappings
+func classMapping(value interface{}, class string) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]string:
+		if class != "" && v[class] != "" {
+			return v[class]
+		}
+		if v["any"] != "" {
+			return v["any"]
+		}
+	}
+	return ""
+}
+
+// normalizeText standardizes text for comparison
+func normalizeText(text string) string {
+	text = strings.ToLower(text)
+	text = strings.TrimSpace(text)
+
+	// Remove special characters except spaces
+	var result strings.Builder
+	for _, r := range text {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == ' ' {
+			result.WriteRune(r)
+		} else {
+			result.WriteRune(' ')
+		}
+	}
+
+	// Normalize multiple spaces
+	normalized := result.String()
+	normalized = strings.Join(strings.Fields(normalized), " ")
+
+	return normalized
+}
+
+// builtinMappings returns the built-in table of D2R property names to Traderie
+// properties. Skills map to a class-keyed table: the class's own "Only"
+// property, or "any" for the "(Any Class)" one.
+func builtinMappings() map[string]interface{} {
+	return map[string]interface{}{
+		// Skills - Barbarian
+		"Battle Orders": map[string]string{
+			"barbarian": "to Battle Orders (Barbarian Only)",
+			"any":       "to Battle Orders (Any Class)",
+		},
+		"Battle Command": map[string]string{
+			"barbarian": "to Battle Command (Barbarian Only)",
+			"any":       "to Battle Command (Any Class)",
+		},
+		"Whirlwind": map[string]string{
+			"barbarian": "to Whirlwind (Barbarian Only)",
+			"any":       "to Whirlwind (Any Class)",
+		},
+		"Berserk": map[string]string{
+			"barbarian": "to Berserk (Barbarian Only)",
+			"any":       "to Berserk (Any Class)",
+		},
+
+		// Skills - Sorceress
+		"Fireball": map[string]string{
+			"sorceress": "to Fireball (Sorceress Only)",
+			"any":       "to Fireball (Any Class)",
+		},
+		"Fire Ball": map[string]string{
+			"sorceress": "to Fireball (Sorceress Only)",
+			"any":       "to Fireball (Any Class)",
+		},
+		"Frozen Orb": map[string]string{
+			"sorceress": "to Frozen Orb (Sorceress Only)",
+			"any":       "to Frozen Orb (Any Class)",
+		},
+		"Blizzard": map[string]string{
+			"sorceress": "to Blizzard (Sorceress Only)",
+			"any":       "to Blizzard (Any Class)",
+		},
+		"Chain Lightning": map[string]string{
+			"sorceress": "to Chain Lightning (Sorceress Only)",
+			"any":       "to Chain Lightning (Any Class)",
+		},
+		"Lightning": map[string]string{
+			"sorceress": "to Lightning (Sorceress Only)",
+			"any":       "to Lightning (Any Class)",
+		},
+		"Teleport": map[string]string{
+			"sorceress": "to Teleport (Sorceress Only)",
+			"any":       "to Teleport (Any Class)",
+		},
+
+		// Skills - Paladin
+		"Holy Shield": map[string]string{
+			"paladin": "to Holy Shield (Paladin Only)",
+			"any":     "to Holy Shield (Any Class)",
+		},
+		"Blessed Hammer": map[string]string{
+			"paladin": "to Blessed Hammer (Paladin Only)",
+			"any":     "to Blessed Hammer (Any Class)",
+		},
+		"Concentration": map[string]string{
+			"paladin": "to Concentration (Paladin Only)",
+			"any":     "to Concentration (Any Class)",
+		},
+		"Fanaticism": map[string]string{
+			"paladin": "to Fanaticism (Paladin Only)",
+			"any":     "to Fanaticism (Any Class)",
+		},
+		"Conviction": map[string]string{
+			"paladin": "to Conviction (Paladin Only)",
+			"any":     "to Conviction (Any Class)",
+		},
+
+		// General skill modifiers
+		"All Skills":               "to All Skills",
+		"All Skill Levels":         "to All Skills",
+		"Barbarian Skill Levels":   "to Barbarian Skill Levels",
+		"Sorceress Skill Levels":   "to Sorceress Skill",
+		"Paladin Skill Levels":     "to Paladin Skill",
+		"Necromancer Skill Levels": "to Necromancer Skill",
+		"Amazon Skill Levels":      "to Amazon Skill",
+		"Druid Skill Levels":       "to Druid Skill",
+		"Assassin Skill Levels":    "to Assassin Skill",
+		"Lightning Damage":         "Lightning Damage",
+		"Adds Lightning Damage":    "Lightning Damage",
+
+		// Stats
+		"Faster Cast Rate":             "% Faster Cast Rate",
+		"Faster Hit Recovery":          "% Faster Hit Recovery",
+		"Increased Attack Speed":       "% Increased Attack Speed",
+		"Faster Run/Walk":              "% Faster Run/Walk",
+		"Faster Block Rate":            "% Faster Block Rate",
+		"Enhanced Damage":              "% Enhanced Damage",
+		"Enhanced Defense":             "% Enhanced Defense",
+		"Increased Maximum Durability": "% Increased Maximum Durability",
+
+		// Life and Mana
+		"Life":         "to Life",
+		"Maximum Life": "to Life",
+		"Mana":         "to Mana",
+		"Maximum Mana": "to Mana",
+
+		// Attributes
+		"Strength":  "to Strength",
+		"Dexterity": "to Dexterity",
+		"Vitality":  "to Vitality",
+		"Energy":    "to Energy",
+
+		// Stealing
+		"Life Stolen Per Hit": "% Life Stolen Per Hit",
+		"Mana Stolen Per Hit": "% Mana Stolen Per Hit",
+
+		// Resistances
+		"All Resistances":  "to All Resistances",
+		"Fire Resist":      "% Fire Resist",
+		"Cold Resist":      "% Cold Resist",
+		"Lightning Resist": "% Lightning Resist",
+		"Poison Resist":    "% Poison Resist",
+
+		// Magic Find
+		"Magic Find":                           "% Better Chance of Getting Magic Items",
+		"Better Chance of Getting Magic Items": "% Better Chance of Getting Magic Items",
+
+		// Combat modifiers
+		"Deadly Strike":         "% Deadly Strike",
+		"Crushing Blow":         "% Crushing Blow",
+		"Open Wounds":           "% Open Wounds",
+		"Chance of Open Wounds": "% Open Wounds",
+
+		// Other
+		"Defense":                "Defense",
+		"Damage":                 "Damage",
+		"Attack Rating":          "to Attack Rating",
+		"Bonus to Attack Rating": "to Attack Rating",
+		"Sockets":                "Sockets",
+		"Socket":                 "Sockets",
+		"Replenish Life":         "Replenish Life",
+		"Regenerate Mana":        "Regenerate Mana",
+		"Damage Reduced":         "Damage Reduced",
+		"Magic Damage Reduced":   "Magic Damage Reduced",
+		"Cannot Be Frozen":       "Cannot Be Frozen",
+		"Ignore Target Defense":  "Ignore Target's Defense",
+		"Prevent Monster Heal":   "Prevent Monster Heal",
+		"Half Freeze Duration":   "Half Freeze Duration",
+		"Light Radius":           "to Light Radius",
+	}
+}
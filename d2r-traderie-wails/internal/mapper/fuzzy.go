@@ -0,0 +1,135 @@
+package mapper
+
+import (
+	"sort"
+	"strings"
+)
+
+// MinConfidence is the lowest fuzzy match score that's applied automatically;
+// weaker matches are only offered as alternatives
+const MinConfidence = 0.6
+
+// Ranked candidates below minAlternative share little more than a letter or
+// two with the property and aren't worth offering; at most maxAlternatives are kept
+const (
+	minAlternative  = 0.2
+	maxAlternatives = 5
+)
+
+// Match is a candidate Traderie property scored against a D2R property
+type Match struct {
+	Property   string  `json:"property"`
+	Confidence float64 `json:"confidence"` // 0 (nothing in common) to 1 (same words)
+}
+
+// stopWords carry no meaning when comparing property names
+var stopWords = map[string]bool{
+	"to": true, "of": true, "the": true, "and": true, "on": true, "only": true,
+}
+
+// RankCandidates scores every candidate against a D2R property name and
+// returns the best ones, highest confidence first. The score blends word
+// overlap with edit distance, so "Faster Cast Rate" matches "Faster Cast
+// Rate %" closely while sharing one word scores low. Ties are broken by
+// name so the ranking is the same on every run.
+func RankCandidates(name string, candidates []string) []Match {
+	query := normalizeText(name)
+	if query == "" {
+		return nil
+	}
+	queryTokens := tokens(query)
+
+	matches := make([]Match, 0, len(candidates))
+	for _, candidate := range candidates {
+		normalized := normalizeText(candidate)
+		if normalized == "" {
+			continue
+		}
+		score := 0.6*tokenOverlap(queryTokens, tokens(normalized)) + 0.4*similarity(query, normalized)
+		if score >= minAlternative {
+			matches = append(matches, Match{Property: candidate, Confidence: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		return matches[i].Property < matches[j].Property
+	})
+	if len(matches) > maxAlternatives {
+		matches = matches[:maxAlternatives]
+	}
+	return matches
+}
+
+// tokens splits normalized text into its meaningful words
+func tokens(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(text) {
+		if !stopWords[word] {
+			set[word] = true
+		}
+	}
+	return set
+}
+
+// tokenOverlap is the Jaccard index of two word sets
+func tokenOverlap(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// similarity turns the edit distance between two strings into a 0-1 score
+func similarity(a, b string) float64 {
+	longest := len([]rune(a))
+	if n := len([]rune(b)); n > longest {
+		longest = n
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,189 @@
+package mapper
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+func TestRankCandidates(t *testing.T) {
+	candidates := []string{"Faster Cast Rate", "Faster Run/Walk", "Fire Resist", "Cold Resist", "Lightning Resist", "to Life", "Life after each Kill", "Fire Resist %"}
+
+	tests := []struct {
+		name       string
+		property   string
+		candidates []string
+		want       []string
+	}{
+		{
+			name:       "closest first",
+			property:   "Faster Cast Rte",
+			candidates: candidates,
+			want:       []string{"Faster Cast Rate", "Faster Run/Walk"},
+		},
+		{
+			name:       "exact match ranks above shared words",
+			property:   "Cold Resist",
+			candidates: candidates,
+			want:       []string{"Cold Resist", "Fire Resist", "Fire Resist %", "Lightning Resist"},
+		},
+		{
+			name:       "stop words ignored",
+			property:   "Life",
+			candidates: candidates,
+			want:       []string{"to Life", "Life after each Kill"},
+		},
+		{
+			name:       "ties broken by name",
+			property:   "Resist",
+			candidates: []string{"Fire Resist", "Cold Resist"},
+			want:       []string{"Cold Resist", "Fire Resist"},
+		},
+		{
+			name:     "skill proc triggers told apart",
+			property: "10% Chance to cast level 7 Frost Nova when you Level-Up",
+			candidates: []string{
+				"% Chance to Cast Level 7 Frost Nova on Striking",
+				"% Chance to Cast Level 7 Frost Nova When You Level-Up",
+			},
+			want: []string{"% Chance to Cast Level 7 Frost Nova When You Level-Up", "% Chance to Cast Level 7 Frost Nova on Striking"},
+		},
+		{
+			name:       "unrelated candidates dropped",
+			property:   "Xyzzy",
+			candidates: candidates,
+			want:       nil,
+		},
+		{
+			name:       "empty property",
+			property:   "",
+			candidates: candidates,
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range RankCandidates(tt.property, tt.candidates) {
+				got = append(got, m.Property)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RankCandidates(%q) = %q, want %q", tt.property, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchSkillProc(t *testing.T) {
+	candidates := []string{
+		"% Chance to Cast Level 7 Frost Nova on Striking",
+		"% Chance to Cast Level 7 Frost Nova When You Die",
+		"% Chance to Cast Level 7 Frost Nova When You Level-Up",
+		"Frost Nova Charges",
+	}
+
+	tests := []struct {
+		trigger    string
+		maxCharges int
+		want       string
+	}{
+		{"striking", 0, "% Chance to Cast Level 7 Frost Nova on Striking"},
+		{"death", 0, "% Chance to Cast Level 7 Frost Nova When You Die"},
+		{"level-up", 0, "% Chance to Cast Level 7 Frost Nova When You Level-Up"},
+		{"", 20, "Frost Nova Charges"},
+	}
+
+	for _, tt := range tests {
+		proc := &models.SkillProc{Skill: "Frost Nova", Level: 7, Trigger: tt.trigger, MaxCharges: tt.maxCharges}
+		if got := MatchSkillProc(proc, candidates); got != tt.want {
+			t.Errorf("MatchSkillProc(%q) = %q, want %q", tt.trigger, got, tt.want)
+		}
+	}
+}
+
+func TestRankCandidatesScores(t *testing.T) {
+	tests := []struct {
+		property  string
+		candidate string
+		want      float64
+	}{
+		{"Cold Resist", "Cold Resist", 1},
+		{"Faster Cast Rte", "Faster Cast Rate", 0.675},
+		{"Fire Res", "Fire Resist", 0.491},
+		{"Fire Res", "Fire Resist %", 0.491}, // Symbols are ignored
+	}
+
+	for _, tt := range tests {
+		matches := RankCandidates(tt.property, []string{tt.candidate})
+		if len(matches) != 1 {
+			t.Fatalf("RankCandidates(%q, %q) returned %d matches, want 1", tt.property, tt.candidate, len(matches))
+		}
+		if math.Abs(matches[0].Confidence-tt.want) > 0.001 {
+			t.Errorf("RankCandidates(%q, %q) confidence = %.3f, want %.3f", tt.property, tt.candidate, matches[0].Confidence, tt.want)
+		}
+	}
+}
+
+func TestRankCandidatesLimit(t *testing.T) {
+	candidates := []string{"Fire Resist", "Cold Resist", "Lightning Resist", "Poison Resist", "Magic Resist", "All Resist", "Curse Resist"}
+	if got := RankCandidates("Resist", candidates); len(got) != maxAlternatives {
+		t.Errorf("RankCandidates kept %d candidates, want %d", len(got), maxAlternatives)
+	}
+}
+
+func TestResolveFuzzyFallback(t *testing.T) {
+	tests := []struct {
+		name             string
+		property         string
+		candidates       []string
+		wantRule         string
+		wantTraderie     string
+		wantDetail       string
+		wantAlternatives int
+	}{
+		{
+			name:             "confident match applied",
+			property:         "Faster Cast Rte",
+			candidates:       []string{"Faster Cast Rate", "Faster Run/Walk"},
+			wantRule:         RuleFuzzy,
+			wantTraderie:     "Faster Cast Rate",
+			wantDetail:       "fuzzy match, 68% confidence",
+			wantAlternatives: 2,
+		},
+		{
+			name:             "below MinConfidence only offered",
+			property:         "Fire Res",
+			candidates:       []string{"Fire Resist", "Cold Resist"},
+			wantRule:         RuleNone,
+			wantDetail:       `no confident match; best was "Fire Resist" at 49%`,
+			wantAlternatives: 1,
+		},
+		{
+			name:       "no candidates",
+			property:   "Fire Res",
+			wantRule:   RuleNone,
+			wantDetail: "no mapping found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newTestMapper(t)
+			res := pm.Resolve(models.Property{Name: tt.property}, Context{Candidates: tt.candidates})
+
+			if res.Rule != tt.wantRule || res.Traderie != tt.wantTraderie || res.Detail != tt.wantDetail {
+				t.Errorf("Resolve(%q) = {Rule: %q, Traderie: %q, Detail: %q}, want {Rule: %q, Traderie: %q, Detail: %q}",
+					tt.property, res.Rule, res.Traderie, res.Detail, tt.wantRule, tt.wantTraderie, tt.wantDetail)
+			}
+			if len(res.Alternatives) != tt.wantAlternatives {
+				t.Errorf("Resolve(%q) offered %d alternatives, want %d", tt.property, len(res.Alternatives), tt.wantAlternatives)
+			}
+			if tt.wantRule == RuleFuzzy && res.Confidence < MinConfidence {
+				t.Errorf("Resolve(%q) applied a match below MinConfidence: %.2f", tt.property, res.Confidence)
+			}
+		})
+	}
+}
@@ -6,19 +6,62 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// Rules a mapping can come from, in order of precedence
+const (
+	RuleItem    = "item"    // Learned for this Traderie item
+	RuleClass   = "class"   // Learned for the character class holding the item
+	RuleGlobal  = "global"  // Learned for every item
+	RuleBuiltin = "builtin" // Built-in table, stat catalog or skill proc matching
+	RuleFuzzy   = "fuzzy"   // Best-scoring property of the Traderie item, see RankCandidates
+	RuleNone    = "none"    // Nothing matched; the user has to pick
 )
 
 // PropertyMapping represents a learned mapping between D2R and Traderie properties
 type PropertyMapping struct {
-	D2RProperty      string `json:"d2r_property"`       // Property name from d2go
-	TraderieProperty string `json:"traderie_property"`  // Property name in Traderie
-	ItemName         string `json:"item_name"`          // Item this mapping applies to (empty = all items)
+	D2RProperty      string `json:"d2r_property"`      // Property name from d2go
+	TraderieProperty string `json:"traderie_property"` // Property name in Traderie
+	ItemName         string `json:"item_name"`         // Item this mapping applies to (empty = all items)
+	Class            string `json:"class,omitempty"`   // Character class this mapping applies to (empty = all classes)
+}
+
+// Scope limits a learned mapping to one Traderie item or one character class.
+// The zero Scope applies everywhere.
+type Scope struct {
+	ItemName string `json:"itemName,omitempty"`
+	Class    string `json:"class,omitempty"`
+}
+
+// Context is what's known about the item a property is resolved for
+type Context struct {
+	ItemName   string   // Traderie item name
+	Class      string   // Class of the character holding the item, lowercase
+	Candidates []string // Properties of the Traderie item, when known
+}
+
+// Resolution is the Traderie property a D2R property maps to and why
+type Resolution struct {
+	D2RProperty string `json:"d2rProp"`
+	Traderie    string `json:"traderieProp"` // Empty when nothing matched
+	Rule        string `json:"rule"`
+	Detail      string `json:"detail"` // Human-readable explanation of the rule
+
+	Confidence   float64 `json:"confidence,omitempty"`   // Score of a fuzzy match
+	Alternatives []Match `json:"alternatives,omitempty"` // Ranked fuzzy candidates, also when none was confident enough
 }
 
-// PropertyMapper handles learning and applying property mappings
+// PropertyMapper is the mapping engine: it resolves D2R property names to
+// Traderie properties from learned mappings, the built-in table and fuzzy matching
 type PropertyMapper struct {
-	mappings map[string]PropertyMapping // Key: D2RProperty
+	mappings map[string]PropertyMapping // Key: scope prefix + D2RProperty, see mappingKey
+	builtin  map[string]interface{}     // Built-in table; values are a name or a class-keyed map
+	keys     []string                   // Built-in table keys, sorted so lookups are deterministic
 	mu       sync.RWMutex
 	filePath string
 }
@@ -30,8 +73,13 @@ func NewPropertyMapper() *PropertyMapper {
 
 	pm := &PropertyMapper{
 		mappings: make(map[string]PropertyMapping),
+		builtin:  newBuiltinTable(),
 		filePath: mappingsPath,
 	}
+	for key := range pm.builtin {
+		pm.keys = append(pm.keys, key)
+	}
+	sort.Strings(pm.keys)
 
 	// Load existing mappings
 	pm.Load()
@@ -68,11 +116,7 @@ func (pm *PropertyMapper) Load() error {
 
 	// Load into map
 	for _, m := range mappingsList {
-		key := m.D2RProperty
-		if m.ItemName != "" {
-			key = m.ItemName + ":" + m.D2RProperty
-		}
-		pm.mappings[key] = m
+		pm.mappings[mappingKey(m.D2RProperty, Scope{ItemName: m.ItemName, Class: m.Class})] = m
 	}
 
 	log.Printf("✓ Loaded %d saved property mappings", len(pm.mappings))
@@ -105,44 +149,104 @@ func (pm *PropertyMapper) Save() error {
 	return nil
 }
 
-// GetMapping retrieves a learned mapping
-func (pm *PropertyMapper) GetMapping(d2rProperty, itemName string) (string, bool) {
+// Resolve maps a D2R property to a Traderie property. The first rule that
+// matches wins: a mapping learned for the item, then one learned for the
+// class, then a global one, then the built-in table, then the Traderie
+// item's property that scores best against the name, if it scores at least
+// MinConfidence.
+func (pm *PropertyMapper) Resolve(prop models.Property, ctx Context) Resolution {
+	name := cleanPropertyName(prop.Name)
+	res := Resolution{D2RProperty: name, Rule: RuleNone, Detail: "no mapping found"}
+
+	if m, rule, ok := pm.learned(name, ctx); ok {
+		res.Traderie, res.Rule = m.TraderieProperty, rule
+		switch rule {
+		case RuleItem:
+			res.Detail = fmt.Sprintf("learned for %s", m.ItemName)
+		case RuleClass:
+			res.Detail = fmt.Sprintf("learned for %s characters", m.Class)
+		default:
+			res.Detail = "learned for all items"
+		}
+		return res
+	}
+
+	if traderie, detail := pm.builtinMatch(prop, name, ctx); traderie != "" {
+		res.Traderie, res.Rule, res.Detail = traderie, RuleBuiltin, detail
+		return res
+	}
+
+	res.Alternatives = RankCandidates(name, ctx.Candidates)
+	if len(res.Alternatives) > 0 {
+		best := res.Alternatives[0]
+		res.Confidence = best.Confidence
+		if best.Confidence < MinConfidence {
+			res.Detail = fmt.Sprintf("no confident match; best was %q at %.0f%%", best.Property, best.Confidence*100)
+			return res
+		}
+		res.Traderie, res.Rule = best.Property, RuleFuzzy
+		res.Detail = fmt.Sprintf("fuzzy match, %.0f%% confidence", best.Confidence*100)
+	}
+	return res
+}
+
+// learned returns the most specific learned mapping for a property
+func (pm *PropertyMapper) learned(d2rProperty string, ctx Context) (PropertyMapping, string, bool) {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
 
-	// Try item-specific mapping first
-	if itemName != "" {
-		key := itemName + ":" + d2rProperty
-		if m, ok := pm.mappings[key]; ok {
-			return m.TraderieProperty, true
+	if ctx.ItemName != "" {
+		if m, ok := pm.mappings[mappingKey(d2rProperty, Scope{ItemName: ctx.ItemName})]; ok {
+			return m, RuleItem, true
 		}
 	}
+	if ctx.Class != "" {
+		if m, ok := pm.mappings[mappingKey(d2rProperty, Scope{Class: ctx.Class})]; ok {
+			return m, RuleClass, true
+		}
+	}
+	if m, ok := pm.mappings[mappingKey(d2rProperty, Scope{})]; ok {
+		return m, RuleGlobal, true
+	}
+	return PropertyMapping{}, "", false
+}
 
-	// Try global mapping
-	if m, ok := pm.mappings[d2rProperty]; ok {
-		return m.TraderieProperty, true
+// builtinMatch maps skill procs and stats that name their Traderie property
+// when the Traderie item has it, then looks the name up in the built-in table
+func (pm *PropertyMapper) builtinMatch(prop models.Property, name string, ctx Context) (string, string) {
+	if proc := MatchSkillProc(prop.Skill, ctx.Candidates); proc != "" {
+		return proc, "skill and trigger match"
+	}
+	if prop.Traderie != "" && containsFold(ctx.Candidates, prop.Traderie) {
+		return prop.Traderie, "named by the stat catalog"
 	}
 
-	return "", false
+	normalized := normalizeText(name)
+	for _, key := range pm.keys {
+		if normalizeText(key) == normalized {
+			if traderie := classMapping(pm.builtin[key], ctx.Class); traderie != "" {
+				return traderie, fmt.Sprintf("built-in mapping for %q", key)
+			}
+		}
+	}
+	return "", ""
 }
 
-// LearnMapping adds or updates a mapping
-func (pm *PropertyMapper) LearnMapping(d2rProperty, traderieProperty, itemName string) {
+// LearnMapping adds or updates a mapping for the given scope
+func (pm *PropertyMapper) LearnMapping(d2rProperty, traderieProperty string, scope Scope) {
+	d2rProperty = cleanPropertyName(d2rProperty)
+
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	key := d2rProperty
-	if itemName != "" {
-		key = itemName + ":" + d2rProperty
-	}
-
-	pm.mappings[key] = PropertyMapping{
+	pm.mappings[mappingKey(d2rProperty, scope)] = PropertyMapping{
 		D2RProperty:      d2rProperty,
 		TraderieProperty: traderieProperty,
-		ItemName:         itemName,
+		ItemName:         scope.ItemName,
+		Class:            strings.ToLower(scope.Class),
 	}
 
-	log.Printf("✓ Learned mapping: '%s' -> '%s' (item: %s)", d2rProperty, traderieProperty, itemName)
+	log.Printf("✓ Learned mapping: '%s' -> '%s' (item: %s, class: %s)", d2rProperty, traderieProperty, scope.ItemName, scope.Class)
 }
 
 // GetAllMappings returns all learned mappings
@@ -158,15 +262,40 @@ func (pm *PropertyMapper) GetAllMappings() []PropertyMapping {
 }
 
 // RemoveMapping removes a mapping
-func (pm *PropertyMapper) RemoveMapping(d2rProperty, itemName string) {
+func (pm *PropertyMapper) RemoveMapping(d2rProperty string, scope Scope) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	key := d2rProperty
-	if itemName != "" {
-		key = itemName + ":" + d2rProperty
+	delete(pm.mappings, mappingKey(cleanPropertyName(d2rProperty), scope))
+}
+
+// mappingKey keys a learned mapping by its scope: "Item:prop" for item
+// mappings (the format older mapping files use), "class/barbarian:prop" for
+// class mappings and the bare property name for global ones
+func mappingKey(d2rProperty string, scope Scope) string {
+	switch {
+	case scope.ItemName != "":
+		return scope.ItemName + ":" + d2rProperty
+	case scope.Class != "":
+		return "class/" + strings.ToLower(scope.Class) + ":" + d2rProperty
 	}
+	return d2rProperty
+}
 
-	delete(pm.mappings, key)
+// cleanPropertyName drops the value from a UI property string, e.g. "to Life: 50" -> "to Life"
+func cleanPropertyName(d2rProperty string) string {
+	if idx := strings.Index(d2rProperty, ":"); idx != -1 {
+		return strings.TrimSpace(d2rProperty[:idx])
+	}
+	return d2rProperty
 }
 
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// newTestMapper returns a mapper whose mapping file lives in a temp dir
+func newTestMapper(t *testing.T) *PropertyMapper {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+	return NewPropertyMapper()
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	const item, class = "Harlequin Crest", "sorceress"
+	candidates := []string{"to Life", "Maximum Life Bonus", "Faster Cast Rate"}
+
+	itemScope := Scope{ItemName: item}
+	classScope := Scope{Class: class}
+	globalScope := Scope{}
+
+	tests := []struct {
+		name         string
+		property     string
+		learn        []Scope
+		wantRule     string
+		wantTraderie string
+		wantDetail   string
+	}{
+		{
+			name:         "item beats class, global and builtin",
+			property:     "Maximum Life",
+			learn:        []Scope{itemScope, classScope, globalScope},
+			wantRule:     RuleItem,
+			wantTraderie: "item pick",
+			wantDetail:   "learned for Harlequin Crest",
+		},
+		{
+			name:         "class beats global and builtin",
+			property:     "Maximum Life",
+			learn:        []Scope{classScope, globalScope},
+			wantRule:     RuleClass,
+			wantTraderie: "class pick",
+			wantDetail:   "learned for sorceress characters",
+		},
+		{
+			name:         "global beats builtin",
+			property:     "Maximum Life",
+			learn:        []Scope{globalScope},
+			wantRule:     RuleGlobal,
+			wantTraderie: "global pick",
+			wantDetail:   "learned for all items",
+		},
+		{
+			name:         "builtin beats fuzzy",
+			property:     "Maximum Life",
+			wantRule:     RuleBuiltin,
+			wantTraderie: "to Life",
+			wantDetail:   `built-in mapping for "Maximum Life"`,
+		},
+		{
+			name:         "fuzzy when nothing else matches",
+			property:     "Faster Cast Rte",
+			wantRule:     RuleFuzzy,
+			wantTraderie: "Faster Cast Rate",
+			wantDetail:   "fuzzy match, 68% confidence",
+		},
+		{
+			name:         "learned beats fuzzy",
+			property:     "Faster Cast Rte",
+			learn:        []Scope{globalScope},
+			wantRule:     RuleGlobal,
+			wantTraderie: "global pick",
+			wantDetail:   "learned for all items",
+		},
+		{
+			name:       "none when nothing matches",
+			property:   "Xyzzy Plugh",
+			wantRule:   RuleNone,
+			wantDetail: "no mapping found",
+		},
+	}
+
+	picks := map[Scope]string{itemScope: "item pick", classScope: "class pick", globalScope: "global pick"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newTestMapper(t)
+			for _, scope := range tt.learn {
+				pm.LearnMapping(tt.property, picks[scope], scope)
+			}
+
+			res := pm.Resolve(models.Property{Name: tt.property}, Context{ItemName: item, Class: class, Candidates: candidates})
+			if res.Rule != tt.wantRule || res.Traderie != tt.wantTraderie || res.Detail != tt.wantDetail {
+				t.Errorf("Resolve(%q) = {Rule: %q, Traderie: %q, Detail: %q}, want {Rule: %q, Traderie: %q, Detail: %q}",
+					tt.property, res.Rule, res.Traderie, res.Detail, tt.wantRule, tt.wantTraderie, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestResolveScopeMismatch(t *testing.T) {
+	pm := newTestMapper(t)
+	pm.LearnMapping("Maximum Life", "item pick", Scope{ItemName: "Shako"})
+	pm.LearnMapping("Maximum Life", "class pick", Scope{Class: "Barbarian"})
+
+	res := pm.Resolve(models.Property{Name: "Maximum Life"}, Context{ItemName: "Harlequin Crest", Class: "sorceress"})
+	if res.Rule != RuleBuiltin || res.Traderie != "to Life" {
+		t.Errorf("mappings for another item and class applied: got {Rule: %q, Traderie: %q}", res.Rule, res.Traderie)
+	}
+
+	res = pm.Resolve(models.Property{Name: "Maximum Life"}, Context{Class: "barbarian"})
+	if res.Rule != RuleClass || res.Detail != "learned for barbarian characters" {
+		t.Errorf("class mapping not matched case-insensitively: got {Rule: %q, Detail: %q}", res.Rule, res.Detail)
+	}
+}
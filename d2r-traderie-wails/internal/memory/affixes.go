@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// AffixNamer resolves affix IDs to names using the game's data tables
+type AffixNamer interface {
+	MagicPrefixName(id int) string
+	MagicSuffixName(id int) string
+	RarePrefixName(id int) string
+	RareSuffixName(id int) string
+}
+
+var (
+	affixNamer   AffixNamer
+	affixNamerMu sync.RWMutex
+)
+
+// SetAffixNamer installs the affix name tables used when parsing items
+func SetAffixNamer(namer AffixNamer) {
+	affixNamerMu.Lock()
+	defer affixNamerMu.Unlock()
+	affixNamer = namer
+}
+
+// currentAffixNamer returns the installed affix name tables, or the game data
+// tables when none were installed
+func currentAffixNamer() AffixNamer {
+	affixNamerMu.RLock()
+	defer affixNamerMu.RUnlock()
+	if affixNamer == nil {
+		return gamedata.Default()
+	}
+	return affixNamer
+}
+
+// readAffixes returns the prefix and suffix IDs the game rolled on a magic,
+// rare or crafted item, with names where they can be resolved
+func readAffixes(d2item *data.Item) *models.Affixes {
+	var prefixes, suffixes []int
+	for _, id := range d2item.Affixes.Magic.Prefixes {
+		prefixes = append(prefixes, int(id))
+	}
+	for _, id := range d2item.Affixes.Magic.Suffixes {
+		suffixes = append(suffixes, int(id))
+	}
+
+	return newAffixes(d2item.Quality.ToString(), prefixes, suffixes,
+		int(d2item.Affixes.Rare.Prefix), int(d2item.Affixes.Rare.Suffix))
+}
+
+// newAffixes names the affix IDs of a magic, rare or crafted item. Zero IDs
+// are empty slots. It returns nil for other qualities.
+func newAffixes(quality string, prefixes, suffixes []int, rarePrefix, rareSuffix int) *models.Affixes {
+	if quality != "Magic" && quality != "Rare" && quality != "Crafted" {
+		return nil
+	}
+
+	namer := currentAffixNamer()
+	affixes := &models.Affixes{}
+
+	for _, id := range prefixes {
+		if id != 0 {
+			affix := models.Affix{ID: id, Name: namer.MagicPrefixName(id)}
+			affixes.Prefixes = append(affixes.Prefixes, affix)
+		}
+	}
+	for _, id := range suffixes {
+		if id != 0 {
+			affix := models.Affix{ID: id, Name: namer.MagicSuffixName(id)}
+			affixes.Suffixes = append(affixes.Suffixes, affix)
+		}
+	}
+
+	if quality != "Magic" {
+		affixes.RarePrefix = &models.Affix{ID: rarePrefix, Name: namer.RarePrefixName(rarePrefix)}
+		affixes.RareSuffix = &models.Affix{ID: rareSuffix, Name: namer.RareSuffixName(rareSuffix)}
+	}
+
+	return affixes
+}
+
+// magicName builds a magic item's name, e.g. "Shimmering Small Charm of Vita"
+func magicName(baseName string, affixes *models.Affixes) string {
+	parts := []string{}
+	for _, p := range affixes.Prefixes {
+		if p.Name != "" {
+			parts = append(parts, p.Name)
+			break
+		}
+	}
+	parts = append(parts, baseName)
+	for _, s := range affixes.Suffixes {
+		if s.Name != "" {
+			parts = append(parts, s.Name)
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// rareName builds a rare or crafted item's two-word name, e.g. "Storm Turn"
+func rareName(affixes *models.Affixes) string {
+	if affixes == nil || affixes.RarePrefix == nil || affixes.RareSuffix == nil ||
+		affixes.RarePrefix.Name == "" || affixes.RareSuffix.Name == "" {
+		return ""
+	}
+	return affixes.RarePrefix.Name + " " + affixes.RareSuffix.Name
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+)
+
+// fakeNamer names affixes from fixed tables
+type fakeNamer struct {
+	magic map[int]string
+	rare  map[int]string
+}
+
+func (n fakeNamer) MagicPrefixName(id int) string { return n.magic[id] }
+func (n fakeNamer) MagicSuffixName(id int) string { return n.magic[id] }
+func (n fakeNamer) RarePrefixName(id int) string  { return n.rare[id] }
+func (n fakeNamer) RareSuffixName(id int) string  { return n.rare[id] }
+
+// withAffixNamer installs a namer for the duration of a test
+func withAffixNamer(t *testing.T, namer AffixNamer) {
+	t.Helper()
+	SetAffixNamer(namer)
+	t.Cleanup(func() { SetAffixNamer(nil) })
+}
+
+func TestNewAffixesNamesFromTables(t *testing.T) {
+	withAffixNamer(t, fakeNamer{
+		magic: map[int]string{101: "Cobalt", 202: "of the Fox"},
+		rare:  map[int]string{7: "Storm", 9: "Turn"},
+	})
+
+	magic := newAffixes("Magic", []int{101, 0}, []int{202}, 0, 0)
+	if got := magicName("Small Charm", magic); got != "Cobalt Small Charm of the Fox" {
+		t.Errorf("magic name = %q, want %q", got, "Cobalt Small Charm of the Fox")
+	}
+	if len(magic.Prefixes) != 1 {
+		t.Errorf("empty prefix slots kept: %+v", magic.Prefixes)
+	}
+
+	rare := newAffixes("Rare", nil, nil, 7, 9)
+	if got := rareName(rare); got != "Storm Turn" {
+		t.Errorf("rare name = %q, want %q", got, "Storm Turn")
+	}
+
+	if newAffixes("Unique", []int{101}, nil, 0, 0) != nil {
+		t.Error("unique items got affixes")
+	}
+}
+
+func TestRareNameFromEmbeddedTables(t *testing.T) {
+	data, err := gamedata.Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	withAffixNamer(t, data)
+
+	// The first word indexes past the suffixes into RarePrefix.txt
+	rare := newAffixes("Rare", nil, nil, 157, 1)
+	if got := rareName(rare); got != "Eagle Bite" {
+		t.Errorf("rare name = %q, want %q", got, "Eagle Bite")
+	}
+}
+
+func TestNewAffixesUnknownIDs(t *testing.T) {
+	// IDs past the end of the tables stay unnamed
+	withAffixNamer(t, fakeNamer{})
+
+	affixes := newAffixes("Magic", []int{44}, nil, 0, 0)
+	if got := magicName("Small Charm", affixes); got != "Small Charm" {
+		t.Errorf("magic name = %q, want %q", got, "Small Charm")
+	}
+
+	rare := newAffixes("Rare", nil, nil, 7, 9)
+	if got := rareName(rare); got != "" {
+		t.Errorf("rare name without names = %q, want empty", got)
+	}
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// CaptureVersion is bumped whenever the capture file layout changes
+const CaptureVersion = 1
+
+// Capture is a recorded scan: the raw d2go item exactly as the reader
+// received it, next to the parsed item we produced from it
+type Capture struct {
+	Version    int          `json:"version"`
+	CapturedAt time.Time    `json:"captured_at"`
+	Item       data.Item    `json:"item"`
+	Parsed     *models.Item `json:"parsed"`
+}
+
+// WriteCapture serializes a scan to a new capture file in dir and returns its path
+func WriteCapture(dir string, d2item *data.Item, parsed *models.Item) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create capture directory: %w", err)
+	}
+
+	capture := Capture{
+		Version:    CaptureVersion,
+		CapturedAt: time.Now(),
+		Item:       *d2item,
+		Parsed:     parsed,
+	}
+
+	data, err := json.MarshalIndent(capture, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal capture: %w", err)
+	}
+
+	name := fmt.Sprintf("%s_%d.json", capture.CapturedAt.Format("20060102-150405.000"), d2item.UnitID)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write capture: %w", err)
+	}
+
+	return path, nil
+}
+
+// recordItems writes a capture for every item of a full scan. d2items and
+// items are in the same order. Failures are logged and the rest still written.
+func recordItems(dir string, d2items []*data.Item, items []*models.Item) {
+	recorded := 0
+	for i, d2item := range d2items {
+		if _, err := WriteCapture(dir, d2item, items[i]); err != nil {
+			log.Printf("⚠️ Failed to record %s: %v", d2item.Name, err)
+			continue
+		}
+		recorded++
+	}
+	log.Printf("✓ Recorded %d scanned items to %s", recorded, dir)
+}
+
+// readCapture parses a capture file, rejecting versions newer than we understand
+func readCapture(raw []byte) (*Capture, error) {
+	var capture Capture
+	if err := json.Unmarshal(raw, &capture); err != nil {
+		return nil, fmt.Errorf("failed to parse capture: %w", err)
+	}
+	if capture.Version > CaptureVersion {
+		return nil, fmt.Errorf("capture version %d is newer than supported version %d", capture.Version, CaptureVersion)
+	}
+	return &capture, nil
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// framesPerSecond converts stat durations stored in frames to seconds
+const framesPerSecond = 25
+
+// elementalDamage is one elemental min/max stat pair with its optional duration stat
+type elementalDamage struct {
+	element string
+	min     stat.ID
+	max     stat.ID
+	length  stat.ID // 0 when the element has no duration
+}
+
+// elementalDamages lists the elemental damage stats in tooltip order
+var elementalDamages = []elementalDamage{
+	{"Fire", stat.FireMinDamage, stat.FireMaxDamage, 0},
+	{"Lightning", stat.LightningMinDamage, stat.LightningMaxDamage, 0},
+	{"Magic", stat.MagicMinDamage, stat.MagicMaxDamage, 0},
+	{"Cold", stat.ColdMinDamage, stat.ColdMaxDamage, stat.ColdLength},
+	{"Poison", stat.PoisonMinDamage, stat.PoisonMaxDamage, stat.PoisonLength},
+}
+
+// parseElementalDamage turns each elemental min/max pair into a single damage
+// range property. It also returns the stat IDs it consumed so they are not
+// listed again on their own.
+func parseElementalDamage(itemStats stat.Stats) ([]models.Property, map[stat.ID]bool) {
+	var properties []models.Property
+	consumed := make(map[stat.ID]bool)
+
+	for _, d := range elementalDamages {
+		minDmg, hasMin := itemStats.FindStat(d.min, 0)
+		maxDmg, hasMax := itemStats.FindStat(d.max, 0)
+		if !hasMin || !hasMax {
+			continue
+		}
+
+		damage := &models.DamageRange{
+			Min:  minDmg.Value,
+			Max:  maxDmg.Value,
+			Type: d.element,
+		}
+
+		if d.length != 0 {
+			if length, ok := itemStats.FindStat(d.length, 0); ok && length.Value > 0 {
+				damage.Duration = float64(length.Value) / framesPerSecond
+				consumed[d.length] = true
+
+				// Poison is stored per frame in 256ths; the tooltip shows the total
+				if d.element == "Poison" {
+					damage.Min = minDmg.Value * length.Value / 256
+					damage.Max = maxDmg.Value * length.Value / 256
+				}
+			}
+		}
+
+		properties = append(properties, models.Property{
+			Name:   d.element + " Damage",
+			Value:  fmt.Sprintf("%d-%d", damage.Min, damage.Max),
+			Damage: damage,
+		})
+		consumed[d.min] = true
+		consumed[d.max] = true
+	}
+
+	return properties, consumed
+}
+
+// perLevelProperty decodes a "(Based on Character Level)" stat. The value is
+// the one a level 99 character gets, which is what these items trade on.
+func perLevelProperty(entry stats.Entry, value int) models.Property {
+	perLevel := &models.PerLevel{
+		Raw:       value,
+		PerLevel:  float64(value) / float64(int(1)<<entry.PerLevelShift),
+		AtLevel99: entry.AtLevel(value, 99),
+	}
+
+	return models.Property{
+		Name:     entry.Name,
+		Value:    perLevel.AtLevel99,
+		Traderie: entry.Traderie,
+		PerLevel: perLevel,
+	}
+}
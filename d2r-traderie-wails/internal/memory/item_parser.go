@@ -2,9 +2,17 @@ package memory
 
 import (
 	"fmt"
+	"log"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
 )
 
 // ParsePropertyValue attempts to extract numeric value from property text
@@ -108,3 +116,789 @@ func GetCharacterClass(skillText string) string {
 	return ""
 }
 
+
+// Patterns used to read pasted tooltip text
+var (
+	colorCodePattern      = regexp.MustCompile(`ÿc.`)
+	numberPattern         = regexp.MustCompile(`[-+]?\d+`)
+	qualityTagPattern     = regexp.MustCompile(`^\[(\w+)\]\s*(.*)$`)
+	qualitySuffixPattern  = regexp.MustCompile(`^(.*?)\s*\((\w+)\)$`)
+	runeStringPattern     = regexp.MustCompile(`^'([A-Za-z]+)'$`)
+	runeWordPattern       = regexp.MustCompile(`[A-Z][a-z]*`)
+	classOnlyPattern      = regexp.MustCompile(`^\((\w+) Only\)$`)
+	setBonusPattern       = regexp.MustCompile(`(?i)\(\d+ items\)$`)
+	enhancedDamagePattern = regexp.MustCompile(`(?i)^\+?(\d+)% enhanced (?:weapon )?damage$`)
+	addsDamagePattern     = regexp.MustCompile(`(?i)^adds \d+\s*(?:-|to)\s*\d+ (.+)$`)
+	flatDamagePattern     = regexp.MustCompile(`^\+(\d+) (.+)$`)
+	poisonPattern         = regexp.MustCompile(`(?i)^(?:adds \d+\s*(?:-|to)\s*\d+|\+(\d+)) poison damage over (\d+) seconds?$`)
+	skillBonusPattern     = regexp.MustCompile(`^\+(\d+) to (.+?)(?: \((\w+) Only\))?$`)
+	chancePattern         = regexp.MustCompile(`(?i)^(\d+)% chance to cast level (\d+) (.+)$`)
+	chargesPattern        = regexp.MustCompile(`(?i)^level (\d+) (.+) \((\d+)/(\d+) charges\)$`)
+	auraPattern           = regexp.MustCompile(`(?i)^level (\d+) (.+) aura when equipped$`)
+)
+
+// itemQualities are the qualities pasted text can name, e.g. "Quality: Unique" or "[Unique] Shako"
+var itemQualities = []string{"Normal", "Superior", "Magic", "Rare", "Unique", "Set", "Crafted", "Runeword"}
+
+// ignoredTextPrefixes start tooltip lines the item model has no place for
+var ignoredTextPrefixes = []string{
+	"durability:", "quantity:", "throw damage:", "kick damage:",
+	"weapons:", "armor:", "helms:", "shields:",
+	"keep in inventory", "can be inserted", "right click",
+}
+
+// textDamagePairs maps the damage ranges tooltips show as one line to their min and max stats
+var textDamagePairs = map[string][2]int{
+	"damage":           {21, 22},
+	"fire damage":      {48, 49},
+	"lightning damage": {50, 51},
+	"magic damage":     {52, 53},
+	"cold damage":      {54, 55},
+}
+
+// itemText holds an item while its pasted tooltip lines are read
+type itemText struct {
+	data      *gamedata.Data
+	item      *models.Item
+	quality   string   // Quality the text names, if any
+	runes     []string // Codes of the socketed runes from the rune string or a "Runes:" line
+	body      bool     // Set once the name lines are over
+	stats     stat.Stats
+	unknown   []models.Property
+	templates textTemplates
+}
+
+// ParseItemText reads an item from its tooltip copied as text, either as D2R
+// shows it or in the community "Key: value" copy format, so items can be
+// priced and listed without reading game memory
+func ParseItemText(text string) (*models.Item, error) {
+	lines := itemTextLines(text)
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no item text to parse")
+	}
+
+	data := gamedata.Default()
+	p := &itemText{
+		data:      data,
+		item:      &models.Item{IsIdentified: true},
+		templates: newTextTemplates(data),
+	}
+
+	for _, line := range lines {
+		switch {
+		case p.field(line):
+		case !p.body && p.title(line):
+		default:
+			p.body = true
+			p.statLine(line)
+		}
+	}
+
+	if p.item.Name == "" {
+		return nil, fmt.Errorf("no item name found in the text")
+	}
+
+	p.resolve(data)
+	return p.item, nil
+}
+
+// itemTextLines splits pasted text into lines, dropping color codes,
+// markdown and list markers that chat and forum copies add
+func itemTextLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = colorCodePattern.ReplaceAllString(line, "")
+		line = strings.NewReplacer("**", "", "__", "", "`", "").Replace(line)
+		line = strings.TrimSpace(line)
+		for _, marker := range []string{"- ", "* ", "• ", "> "} {
+			line = strings.TrimPrefix(line, marker)
+		}
+		line = strings.TrimSpace(line)
+
+		if strings.Trim(line, "-=_*~") == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// field reads the lines around the stats: "Key: value" pairs, defense,
+// damage, requirements and the ethereal/socketed line. It reports whether
+// the line was one of them.
+func (p *itemText) field(line string) bool {
+	item := p.item
+	lower := strings.ToLower(line)
+
+	if classOnlyPattern.MatchString(line) || setBonusPattern.MatchString(line) || strings.HasSuffix(lower, " attack speed") {
+		p.body = true
+		return true
+	}
+	for _, prefix := range ignoredTextPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			p.body = true
+			return true
+		}
+	}
+
+	switch {
+	case lower == "unidentified":
+		item.IsIdentified = false
+		return true
+	case strings.HasPrefix(lower, "ethereal (cannot be repaired)") || strings.HasPrefix(lower, "socketed ("):
+		item.IsEthereal = item.IsEthereal || strings.HasPrefix(lower, "ethereal")
+		if i := strings.Index(lower, "socketed ("); i >= 0 {
+			if sockets, err := ParsePropertyValue(line[i:]); err == nil {
+				item.Sockets = sockets
+			}
+		}
+		p.body = true
+		return true
+	}
+
+	key, value, ok := strings.Cut(line, ":")
+	if !ok || numberPattern.MatchString(key) {
+		return false
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	value = strings.TrimSpace(value)
+
+	switch key {
+	case "name", "item":
+		p.setName(value)
+	case "base", "base item", "type":
+		item.BaseName = value
+	case "quality", "rarity":
+		p.quality = textQuality(value)
+	case "runeword":
+		item.Runeword = value
+	case "runes":
+		p.runes = runeList(p.data, value)
+	case "item level", "ilvl", "ilevel":
+		if level, err := ParsePropertyValue(value); err == nil {
+			item.ItemLevel = &level
+		}
+	case "sockets":
+		item.Sockets, _ = ParsePropertyValue(value)
+	case "ethereal":
+		item.IsEthereal = strings.EqualFold(value, "yes") || strings.EqualFold(value, "true")
+	case "defense":
+		item.Defense, _ = ParsePropertyValue(value)
+		p.body = true
+	case "one-hand damage", "two-hand damage", "damage":
+		// Two-handed damage wins for weapons that show both, like the memory reader
+		if minDamage, maxDamage, err := ParsePropertyRange(value); err == nil && (item.Damage == nil || key == "two-hand damage") {
+			item.Damage = &models.DamageRange{Min: minDamage, Max: maxDamage, Type: "Physical"}
+		}
+		p.body = true
+	case "required level", "level requirement":
+		p.requirements().Level, _ = ParsePropertyValue(value)
+		p.body = true
+	case "required strength":
+		p.requirements().Strength, _ = ParsePropertyValue(value)
+		p.body = true
+	case "required dexterity":
+		p.requirements().Dexterity, _ = ParsePropertyValue(value)
+		p.body = true
+	default:
+		return false
+	}
+	return true
+}
+
+// title reads the name lines above the stats: the item name, the base name
+// and a runeword's rune string. It reports whether the line was one of them.
+func (p *itemText) title(line string) bool {
+	if m := runeStringPattern.FindStringSubmatch(line); m != nil {
+		p.runes = runeList(p.data, m[1])
+		return true
+	}
+	if numberPattern.MatchString(line) {
+		return false
+	}
+
+	switch {
+	case p.item.Name == "":
+		p.setName(line)
+	case p.item.BaseName == "":
+		// Stats without a number, like "Cannot Be Frozen", can follow a lone name
+		if _, ok := p.parseStat(line); ok {
+			return false
+		}
+		p.item.BaseName = line
+	default:
+		return false
+	}
+	return true
+}
+
+// setName sets the item name, taking a quality tag like "[Unique] Shako" or "Shako (Unique)" off it
+func (p *itemText) setName(name string) {
+	if m := qualityTagPattern.FindStringSubmatch(name); m != nil && textQuality(m[1]) != "" {
+		p.quality, name = textQuality(m[1]), m[2]
+	} else if m := qualitySuffixPattern.FindStringSubmatch(name); m != nil && textQuality(m[2]) != "" {
+		name, p.quality = m[1], textQuality(m[2])
+	}
+	p.item.Name = strings.TrimSpace(name)
+}
+
+// requirements returns the item's requirements, creating them on first use
+func (p *itemText) requirements() *models.Requirements {
+	if p.item.Requirements == nil {
+		p.item.Requirements = &models.Requirements{}
+	}
+	return p.item.Requirements
+}
+
+// statLine reads one stat line, keeping lines it can't read as plain properties
+func (p *itemText) statLine(line string) {
+	if found, ok := p.parseStat(line); ok {
+		p.stats = append(p.stats, found...)
+		return
+	}
+
+	log.Printf("⚠️ Unrecognized tooltip line: %s", line)
+	prop := models.Property{Name: line, Value: true}
+	if value, err := ParsePropertyValue(line); err == nil {
+		// Drop the number with its "%" and a "Key:" colon, e.g. "Foo: 12%" -> "Foo"
+		prop.Name = strings.Replace(line, numberPattern.FindString(line), "", 1)
+		prop.Name = strings.Trim(prop.Name, " :%")
+		prop.Value = value
+	}
+	p.unknown = append(p.unknown, prop)
+}
+
+// parseStat turns a stat line back into the stats it describes
+func (p *itemText) parseStat(line string) (stat.Stats, bool) {
+	if found, ok := parseDamageText(line); ok {
+		return found, true
+	}
+	if found, ok := parseSkillText(line); ok {
+		return found, true
+	}
+	if found, ok := p.templates.parse(line); ok {
+		return found, true
+	}
+
+	// Community copies also write stats as "Fire Resist: 11%"
+	if key, value, ok := strings.Cut(line, ":"); ok && numberPattern.MatchString(value) {
+		return p.templates.parse(strings.TrimSpace(value) + " " + strings.TrimSpace(key))
+	}
+	return nil, false
+}
+
+// resolve works out the quality, base, type and socketed runes once every
+// line has been read, then builds the properties like the memory reader does
+func (p *itemText) resolve(data *gamedata.Data) {
+	item := p.item
+
+	// Runewords are named by a quality tag, their rune string or the runeword table
+	quality := p.quality
+	if quality == "Runeword" {
+		if item.Runeword == "" {
+			item.Runeword = item.Name
+		}
+		quality = ""
+	}
+	if item.Runeword == "" && len(p.runes) > 0 {
+		item.Runeword = item.Name
+		if rw, ok := data.RunewordByRunes(p.runes); ok {
+			item.Runeword = rw.Name
+		}
+	}
+	if item.Runeword == "" && quality == "" && item.BaseName != "" {
+		if _, ok := data.Runeword(item.Name); ok {
+			item.Runeword = item.Name
+		}
+	}
+	if item.Runeword != "" && len(p.runes) == 0 {
+		if rw, ok := data.Runeword(item.Runeword); ok {
+			p.runes = rw.Runes
+		}
+	}
+
+	if quality == "" {
+		quality = guessTextQuality(data, item)
+	}
+	item.Quality = quality
+
+	base, hasBase := textBase(data, item)
+	if hasBase {
+		if item.BaseName == "" {
+			item.BaseName = base.Name
+		}
+		item.Code = base.Code
+		item.Type = base.Type
+	} else {
+		if item.BaseName == "" {
+			item.BaseName = item.Name
+		}
+		item.Type = item.BaseName
+	}
+
+	// Socketed runes add fixed stats that the memory reader lists separately
+	slot := "armor"
+	if hasBase {
+		slot = baseSocketSlot(base)
+	}
+	var filler stat.Stats
+	for _, code := range p.runes {
+		var added stat.Stats
+		if gem, ok := data.Gem(code); ok {
+			added = gemStats(data, gem, slot)
+		}
+		runeBase, _ := data.Base(code)
+		item.SocketedItems = append(item.SocketedItems, models.SocketedItem{
+			Name:       runeBase.Name,
+			Type:       "rune",
+			Properties: statProperties(added),
+			Stats:      rawStats(added),
+		})
+		filler = append(filler, added...)
+	}
+	if item.Sockets < len(p.runes) {
+		item.Sockets = len(p.runes)
+	}
+
+	item.Stats = rawStats(p.stats)
+	item.Properties = append(statProperties(subtractStats(p.stats, filler)), p.unknown...)
+	if item.Sockets > 0 {
+		item.Properties = append(item.Properties, models.Property{Name: "Sockets", Value: item.Sockets})
+	}
+	if item.IsEthereal {
+		item.Properties = append(item.Properties, models.Property{Name: "Ethereal", Value: true})
+	}
+
+	if req := item.Requirements; req != nil && req.Level == 0 && req.Strength == 0 && req.Dexterity == 0 {
+		item.Requirements = nil
+	}
+}
+
+// guessTextQuality works out the quality of an item whose text doesn't name it
+func guessTextQuality(data *gamedata.Data, item *models.Item) string {
+	if item.Runeword != "" {
+		return "Normal"
+	}
+	if _, ok := data.Unique(item.Name); ok {
+		return "Unique"
+	}
+	if _, ok := data.SetItem(item.Name); ok {
+		return "Set"
+	}
+	if item.BaseName != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(item.BaseName)) {
+		// A name line above a base line that isn't a known unique or set,
+		// and doesn't wrap the base name like magic names do
+		return "Rare"
+	}
+	if _, ok := data.BaseByName(item.Name); ok {
+		return "Normal"
+	}
+	if strings.HasPrefix(item.Name, "Superior ") {
+		return "Superior"
+	}
+	if _, ok := baseInName(data, item.Name); ok {
+		// Magic names wrap the base name in their affixes
+		return "Magic"
+	}
+	return "Normal"
+}
+
+// textBase finds the base item of a pasted item
+func textBase(data *gamedata.Data, item *models.Item) (gamedata.ItemBase, bool) {
+	if item.BaseName != "" {
+		if base, ok := data.BaseByName(item.BaseName); ok {
+			return base, true
+		}
+	}
+	if u, ok := data.Unique(item.Name); ok {
+		return data.Base(u.Code)
+	}
+	if s, ok := data.SetItem(item.Name); ok {
+		return data.Base(s.Code)
+	}
+	if base, ok := data.BaseByName(strings.TrimPrefix(item.Name, "Superior ")); ok {
+		return base, true
+	}
+	return baseInName(data, item.Name)
+}
+
+// baseInName returns the longest base item name contained in a magic item's name
+func baseInName(data *gamedata.Data, name string) (gamedata.ItemBase, bool) {
+	lower := strings.ToLower(name)
+	var found gamedata.ItemBase
+	for _, base := range data.Bases() {
+		if len(base.Name) > len(found.Name) && strings.Contains(lower, strings.ToLower(base.Name)) {
+			found = base
+		}
+	}
+	return found, found.Name != ""
+}
+
+// baseSocketSlot tells whether socket bonuses use the weapon, armor or shield column
+func baseSocketSlot(base gamedata.ItemBase) string {
+	if base.MaxDamage > 0 || base.TwoHandMaxDamage > 0 {
+		return "weapon"
+	}
+
+	switch base.Type {
+	case "shie", "ashd", "head":
+		return "shield"
+	}
+	return "armor"
+}
+
+// textQuality returns the quality a word names, or "" when it isn't one
+func textQuality(word string) string {
+	for _, quality := range itemQualities {
+		if strings.EqualFold(quality, strings.TrimSpace(word)) {
+			return quality
+		}
+	}
+	return ""
+}
+
+// runeList reads the rune codes of a rune string ("JahIthBer") or a list ("Jah, Ith, Ber")
+func runeList(data *gamedata.Data, text string) []string {
+	var runes []string
+	for _, word := range runeWordPattern.FindAllString(text, -1) {
+		if base, ok := data.BaseByName(word + " Rune"); ok && base.Type == "rune" {
+			runes = append(runes, base.Code)
+		}
+	}
+	return runes
+}
+
+// parseDamageText reads enhanced damage and the damage ranges the tooltip shows as one line
+func parseDamageText(line string) (stat.Stats, bool) {
+	if m := enhancedDamagePattern.FindStringSubmatch(line); m != nil {
+		value, _ := strconv.Atoi(m[1])
+		return statList(17, value, 18, value), true
+	}
+
+	// Poison damage is stored per frame and spread over its duration
+	if m := poisonPattern.FindStringSubmatch(line); m != nil {
+		seconds, _ := strconv.Atoi(m[2])
+		length := seconds * 25
+		if length == 0 {
+			return nil, false
+		}
+
+		minDamage, maxDamage, err := ParsePropertyRange(line)
+		if err != nil {
+			minDamage, _ = strconv.Atoi(m[1])
+			maxDamage = minDamage
+		}
+		perFrame := func(damage int) int { return (damage*256 + length - 1) / length }
+		return statList(57, perFrame(minDamage), 58, perFrame(maxDamage), 59, length), true
+	}
+
+	if m := addsDamagePattern.FindStringSubmatch(line); m != nil {
+		if pair, ok := textDamagePairs[strings.ToLower(m[1])]; ok {
+			if minDamage, maxDamage, err := ParsePropertyRange(line); err == nil {
+				return statList(pair[0], minDamage, pair[1], maxDamage), true
+			}
+		}
+	}
+
+	// Equal min and max damage is shown as "+X Fire Damage"
+	if m := flatDamagePattern.FindStringSubmatch(line); m != nil {
+		if pair, ok := textDamagePairs[strings.ToLower(m[2])]; ok {
+			value, _ := strconv.Atoi(m[1])
+			return statList(pair[0], value, pair[1], value), true
+		}
+	}
+
+	return nil, false
+}
+
+// parseSkillText reads the stats whose layer holds a skill, class, skill tab
+// or element, the reverse of DescribeSkillStat
+func parseSkillText(line string) (stat.Stats, bool) {
+	if m := chargesPattern.FindStringSubmatch(line); m != nil {
+		if skillID, ok := skillIDByName(m[2]); ok {
+			level, _ := strconv.Atoi(m[1])
+			charges, _ := strconv.Atoi(m[3])
+			maxCharges, _ := strconv.Atoi(m[4])
+			return layeredStat("item_charged_skill", maxCharges<<8|charges, skillID<<6|level)
+		}
+	}
+
+	if m := auraPattern.FindStringSubmatch(line); m != nil {
+		if skillID, ok := skillIDByName(m[2]); ok {
+			level, _ := strconv.Atoi(m[1])
+			return layeredStat("item_aura", level, skillID)
+		}
+	}
+
+	if m := chancePattern.FindStringSubmatch(line); m != nil {
+		chance, _ := strconv.Atoi(m[1])
+		level, _ := strconv.Atoi(m[2])
+		for key, t := range procTriggers {
+			suffix := " " + strings.ToLower(t.phrase)
+			if !strings.HasSuffix(strings.ToLower(m[3]), suffix) {
+				continue
+			}
+			if skillID, ok := skillIDByName(m[3][:len(m[3])-len(suffix)]); ok {
+				return layeredStat(key, chance, skillID<<6|level)
+			}
+		}
+		return nil, false
+	}
+
+	m := skillBonusPattern.FindStringSubmatch(line)
+	if m == nil {
+		return nil, false
+	}
+	value, _ := strconv.Atoi(m[1])
+	target := m[2]
+
+	// "+3 to Warcries (Barbarian Only)" and "+3 to Whirlwind (Barbarian Only)"
+	if only := m[3]; only != "" {
+		class := classIndex(only)
+		if class < 0 {
+			return nil, false
+		}
+		for tab, name := range skillTabNames[class] {
+			if strings.EqualFold(name, target) {
+				return layeredStat("item_addskill_tab", value, class*8+tab)
+			}
+		}
+		if skillID, ok := skillIDByName(target); ok {
+			return layeredStat("item_singleskill", value, skillID)
+		}
+		return nil, false
+	}
+
+	// "+2 to Paladin Skill Levels" and "+1 to Fire Skills"
+	if IsSkillProperty(line) {
+		if class := classIndex(GetCharacterClass(target)); class >= 0 && strings.Contains(strings.ToLower(target), "skill") {
+			return layeredStat("item_addclassskills", value, class)
+		}
+		for element, name := range elementNames {
+			if strings.EqualFold(target, name+" Skills") {
+				return layeredStat("item_elemskill", value, element)
+			}
+		}
+	}
+
+	// "+3 to Teleport" on items usable by any class
+	if skillID, ok := skillIDByName(target); ok {
+		return layeredStat("item_nonclassskill", value, skillID)
+	}
+	return nil, false
+}
+
+// layeredStat builds a single stat from its catalog key
+func layeredStat(key string, value, layer int) (stat.Stats, bool) {
+	for _, entry := range stats.Default().Entries() {
+		if entry.Key == key {
+			return stat.Stats{{ID: stat.ID(entry.ID), Value: value, Layer: layer}}, true
+		}
+	}
+	return nil, false
+}
+
+// classIndex returns a class's position in classNames, or -1
+func classIndex(name string) int {
+	for i, class := range classNames {
+		if strings.EqualFold(class, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+var (
+	skillIDsOnce sync.Once
+	skillIDs     map[string]int
+)
+
+// skillIDByName returns the ID of the skill with the given in-game name
+func skillIDByName(name string) (int, bool) {
+	skillIDsOnce.Do(func() {
+		skillIDs = make(map[string]int)
+		for id, skillName := range skill.SkillNames {
+			key := strings.ToLower(skillName)
+			// Monster skills reuse player skill names; keep the lowest ID
+			if existing, ok := skillIDs[key]; skillName == "" || (ok && existing < int(id)) {
+				continue
+			}
+			skillIDs[key] = int(id)
+		}
+	})
+
+	id, ok := skillIDs[strings.ToLower(strings.TrimSpace(name))]
+	return id, ok
+}
+
+// textTemplate is a stat description from ItemStatCost, used to recognize stat lines
+type textTemplate struct {
+	stats    []gamedata.StatCost // Every member for description groups like "All Resistances"
+	descFunc int
+	flag     bool // On/off effect written without a number, like "Cannot Be Frozen"
+}
+
+// textTemplates indexes stat descriptions by their text with the number
+// taken out. Loose keys also drop "to", so "+40 to All Resistances" matches
+// the game's "All Resistances +40".
+type textTemplates struct {
+	exact map[string]textTemplate
+	loose map[string]textTemplate
+}
+
+// newTextTemplates builds the stat descriptions of the loaded ItemStatCost
+func newTextTemplates(data *gamedata.Data) textTemplates {
+	t := textTemplates{
+		exact: make(map[string]textTemplate),
+		loose: make(map[string]textTemplate),
+	}
+	add := func(text string, tmpl textTemplate) {
+		// The lowest stat ID wins, e.g. mindamage over secondary_mindamage
+		if key := textKey(text); key != "" {
+			if _, exists := t.exact[key]; !exists {
+				t.exact[key] = tmpl
+			}
+		}
+		if key := looseTextKey(text); key != "" {
+			if _, exists := t.loose[key]; !exists {
+				t.loose[key] = tmpl
+			}
+		}
+	}
+
+	groups := make(map[int]bool)
+	for _, sc := range data.Stats() {
+		if sc.DescGroup != 0 {
+			groups[sc.DescGroup] = true
+		}
+		if !describedFunc(sc.DescFunc) {
+			continue
+		}
+		if entry, ok := stats.Default().Lookup(sc.ID); ok && entry.Layered {
+			continue
+		}
+
+		tmpl := textTemplate{stats: []gamedata.StatCost{sc}, descFunc: sc.DescFunc}
+		str2 := data.String(sc.DescStr2)
+		for _, str := range []string{sc.DescStrPos, sc.DescStrNeg} {
+			if str == "" {
+				continue
+			}
+			add(templateText(sc.DescFunc, sc.DescVal, data.String(str), str2), tmpl)
+			if sc.DescFunc == 12 {
+				add(data.String(str), textTemplate{stats: tmpl.stats, descFunc: sc.DescFunc, flag: true})
+			}
+		}
+	}
+
+	for group := range groups {
+		members := data.GroupStats(group)
+		first := members[0]
+		if !describedFunc(first.DescGroupFunc) || first.DescGroupStrPos == "" {
+			continue
+		}
+		tmpl := textTemplate{stats: members, descFunc: first.DescGroupFunc}
+		add(templateText(first.DescGroupFunc, first.DescGroupVal, data.String(first.DescGroupStrPos), data.String(first.DescGroupStr2)), tmpl)
+	}
+
+	return t
+}
+
+// parse turns a stat line into the stats of the description it matches
+func (t textTemplates) parse(line string) (stat.Stats, bool) {
+	tmpl, ok := t.exact[textKey(line)]
+	if !ok {
+		tmpl, ok = t.loose[looseTextKey(line)]
+	}
+	if !ok {
+		return nil, false
+	}
+
+	value := 1
+	if !tmpl.flag {
+		numbers := numberPattern.FindAllString(line, -1)
+		if len(numbers) == 0 {
+			return nil, false
+		}
+		if tmpl.descFunc == 11 {
+			// "Repairs 1 Durability in 20 Seconds"; the last number is the interval
+			value, _ = strconv.Atoi(numbers[len(numbers)-1])
+		} else {
+			value, _ = ParsePropertyValue(line)
+		}
+		value = describedValue(tmpl.descFunc, value)
+	}
+
+	var found stat.Stats
+	for _, sc := range tmpl.stats {
+		v := value
+		if entry, ok := stats.Default().Lookup(sc.ID); ok && entry.Encoding == stats.EncodingPerLevel {
+			// Tooltips show per-level stats at level 99; store the per-level value
+			v = (value<<entry.PerLevelShift + 98) / 99
+		}
+		found = append(found, stat.Data{ID: stat.ID(sc.ID), Value: v})
+	}
+	return found, true
+}
+
+// describedFunc reports whether the tooltip renderer knows a descfunc
+func describedFunc(descFunc int) bool {
+	return (descFunc >= 1 && descFunc <= 12) || descFunc == 19 || descFunc == 20 || descFunc == 21
+}
+
+// describedValue undoes the scaling a descfunc applies to the stored value
+func describedValue(descFunc, shown int) int {
+	switch descFunc {
+	case 5, 10:
+		return (shown*128 + 99) / 100
+	case 11:
+		if shown > 0 {
+			return 100 / shown
+		}
+	case 20, 21:
+		return -shown
+	}
+	return shown
+}
+
+// templateText writes a description with "#" where its number goes
+func templateText(descFunc, descVal int, str1, str2 string) string {
+	if (descFunc == 11 || descFunc == 19) && strings.Contains(str1, "%") {
+		return str1
+	}
+
+	var text string
+	switch descVal {
+	case 0:
+		text = str1
+	case 2:
+		text = str1 + " #"
+	default:
+		text = "# " + str1
+	}
+	if descFunc >= 6 && descFunc <= 10 && str2 != "" {
+		text += " " + str2
+	}
+	return text
+}
+
+// textKey reduces a stat line or description to a comparable key: numbers
+// become "#" and signs, percent marks and letter case are dropped
+func textKey(text string) string {
+	text = strings.NewReplacer("%+d", "#", "%d", "#", "%%", "").Replace(text)
+	text = numberPattern.ReplaceAllString(text, "#")
+	text = strings.NewReplacer("+", "", "%", "").Replace(text)
+	return NormalizePropertyName(text)
+}
+
+// looseTextKey is textKey without the number and "to", for community
+// copies that word or order lines differently
+func looseTextKey(text string) string {
+	var words []string
+	for _, word := range strings.Fields(textKey(text)) {
+		if word != "#" && word != "to" {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+func TestParseItemText(t *testing.T) {
+	data, err := gamedata.Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	gamedata.SetDefault(data)
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+
+	tests := []struct {
+		name     string
+		text     string
+		quality  string
+		base     string
+		code     string
+		runeword string
+		ethereal bool
+		sockets  int
+		stats    []models.Stat
+		unknown  []models.Property // Lines kept as plain properties
+	}{
+		{
+			name: "unique",
+			text: "Harlequin Crest\nShako\nDefense: 141\nDurability: 12 of 12\nRequired Level: 62\n" +
+				"+2 to All Skills\n+148 to Life (Based on Character Level)\nDamage Reduced by 10%\n" +
+				"50% Better Chance of Getting Magic Items\n+2 to all Attributes",
+			quality: "Unique", base: "Shako", code: "uap",
+			stats: []models.Stat{{ID: 127, Value: 2}, {ID: 216, Value: 12}, {ID: 34, Value: 10}, {ID: 80, Value: 50},
+				{ID: 0, Value: 2}, {ID: 1, Value: 2}, {ID: 2, Value: 2}, {ID: 3, Value: 2}},
+		},
+		{
+			name: "runeword with a rune string",
+			text: "Spirit\nCrystal Sword\n'TalThulOrtAmn'\nOne-Hand Damage: 5 to 15\nRequired Level: 54\n" +
+				"+2 to All Skills\n+35% Faster Cast Rate\n+22 to Vitality\nSocketed (4)",
+			quality: "Normal", base: "Crystal Sword", code: "crs", runeword: "Spirit", sockets: 4,
+			stats: []models.Stat{{ID: 127, Value: 2}, {ID: 105, Value: 35}, {ID: 3, Value: 22}},
+		},
+		{
+			name:    "magic charm",
+			text:    "Shimmering Small Charm of Vita\nKeep in Inventory to Gain Bonus\nRequired Level: 22\n+20 to Life\nAll Resistances +5",
+			quality: "Magic", base: "Small Charm", code: "cm1",
+			stats: []models.Stat{{ID: 7, Value: 20}, {ID: 39, Value: 5}, {ID: 41, Value: 5}, {ID: 43, Value: 5}, {ID: 45, Value: 5}},
+		},
+		{
+			name:    "rare",
+			text:    "Storm Turn\nRing\nRequired Level: 30\n+10 to Strength\n10% Faster Cast Rate\nLightning Resist +30%",
+			quality: "Rare", base: "Ring", code: "rin",
+			stats: []models.Stat{{ID: 0, Value: 10}, {ID: 105, Value: 10}, {ID: 41, Value: 30}},
+		},
+		{
+			name:    "ethereal socketed base",
+			text:    "Thresher\nTwo-Hand Damage: 12 to 141\nEthereal (Cannot be Repaired), Socketed (4)",
+			quality: "Normal", base: "Thresher", code: "7s8", ethereal: true, sockets: 4,
+		},
+		{
+			name:    "unrecognized lines",
+			text:    "Grand Charm\nRequired Level: 42\nTotally Made Up Stat\n+7 to Gibberish",
+			quality: "Normal", base: "Grand Charm", code: "cm3",
+			unknown: []models.Property{{Name: "Totally Made Up Stat", Value: true}, {Name: "to Gibberish", Value: 7}},
+		},
+		{
+			name:    "unique as key and value",
+			text:    "Name: Harlequin Crest\nBase: Shako\nQuality: Unique\nDefense: 141\n+2 to All Skills\nMagic Find: 50%",
+			quality: "Unique", base: "Shako", code: "uap",
+			stats:   []models.Stat{{ID: 127, Value: 2}},
+			unknown: []models.Property{{Name: "Magic Find", Value: 50}},
+		},
+		{
+			name:    "runeword as key and value",
+			text:    "Name: Spirit\nBase: Crystal Sword\nRunes: Tal, Thul, Ort, Amn\nFaster Cast Rate: 35%\nVitality: 22",
+			quality: "Normal", base: "Crystal Sword", code: "crs", runeword: "Spirit", sockets: 4,
+			stats: []models.Stat{{ID: 105, Value: 35}, {ID: 3, Value: 22}},
+		},
+		{
+			name:    "magic charm as key and value",
+			text:    "Name: Shimmering Small Charm of Vita\nBase: Small Charm\nLife: 20\nAll Resistances: 5",
+			quality: "Magic", base: "Small Charm", code: "cm1",
+			stats: []models.Stat{{ID: 7, Value: 20}, {ID: 39, Value: 5}, {ID: 41, Value: 5}, {ID: 43, Value: 5}, {ID: 45, Value: 5}},
+		},
+		{
+			name:    "rare as key and value",
+			text:    "Name: Storm Turn\nBase: Ring\nQuality: Rare\nStrength: 10\nLightning Resist: 30%",
+			quality: "Rare", base: "Ring", code: "rin",
+			stats: []models.Stat{{ID: 0, Value: 10}, {ID: 41, Value: 30}},
+		},
+		{
+			name:    "ethereal socketed base as key and value",
+			text:    "Name: Thresher\nEthereal: yes\nSockets: 4",
+			quality: "Normal", base: "Thresher", code: "7s8", ethereal: true, sockets: 4,
+		},
+		{
+			name:    "unrecognized lines as key and value",
+			text:    "Name: Grand Charm\nFoo Bar: 12\nWhatever",
+			quality: "Normal", base: "Grand Charm", code: "cm3",
+			unknown: []models.Property{{Name: "Foo Bar", Value: 12}, {Name: "Whatever", Value: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := ParseItemText(tt.text)
+			if err != nil {
+				t.Fatalf("ParseItemText: %v", err)
+			}
+
+			if item.Quality != tt.quality || item.BaseName != tt.base || item.Code != tt.code || item.Runeword != tt.runeword {
+				t.Errorf("quality, base, code, runeword = %q, %q, %q, %q, want %q, %q, %q, %q",
+					item.Quality, item.BaseName, item.Code, item.Runeword, tt.quality, tt.base, tt.code, tt.runeword)
+			}
+			if item.IsEthereal != tt.ethereal || item.Sockets != tt.sockets {
+				t.Errorf("ethereal, sockets = %v, %d, want %v, %d", item.IsEthereal, item.Sockets, tt.ethereal, tt.sockets)
+			}
+			if len(item.Stats) > 0 || len(tt.stats) > 0 {
+				if !reflect.DeepEqual(item.Stats, tt.stats) {
+					t.Errorf("stats = %+v, want %+v", item.Stats, tt.stats)
+				}
+			}
+			for _, want := range tt.unknown {
+				if !hasProperty(item.Properties, want) {
+					t.Errorf("properties %+v don't include %+v", item.Properties, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseItemTextWithoutName(t *testing.T) {
+	if _, err := ParseItemText("\n---\n"); err == nil {
+		t.Error("ParseItemText accepted text without lines")
+	}
+}
+
+func TestParseItemTextItemLevel(t *testing.T) {
+	item, err := ParseItemText("Name: Grand Charm\nItem Level: 91")
+	if err != nil {
+		t.Fatalf("ParseItemText: %v", err)
+	}
+	if item.ItemLevel == nil || *item.ItemLevel != 91 {
+		t.Errorf("ItemLevel = %v, want 91", item.ItemLevel)
+	}
+
+	// Tooltips don't show the item level, so it stays unknown
+	item, err = ParseItemText("Grand Charm\nRequired Level: 42")
+	if err != nil {
+		t.Fatalf("ParseItemText: %v", err)
+	}
+	if item.ItemLevel != nil {
+		t.Errorf("ItemLevel = %d, want unknown", *item.ItemLevel)
+	}
+}
+
+// hasProperty tells whether properties holds one with the given name and value
+func hasProperty(properties []models.Property, want models.Property) bool {
+	for _, p := range properties {
+		if p.Name == want.Name && reflect.DeepEqual(p.Value, want.Value) {
+			return true
+		}
+	}
+	return false
+}
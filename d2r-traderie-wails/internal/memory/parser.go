@@ -0,0 +1,346 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// parseItem converts d2go item to our internal model
+func parseItem(d2item *data.Item) *models.Item {
+	if d2item == nil {
+		return nil
+	}
+
+	// Determine the item name: use IdentifiedName for unique/set items, otherwise use base name
+	baseName := string(d2item.Name)
+	itemName := baseName
+	quality := d2item.Quality.ToString()
+	affixes := readAffixes(d2item)
+
+	// For Unique, Set, or identified Rare items, use the identified name if available
+	if (quality == "Unique" || quality == "Set" || quality == "Rare" || quality == "Crafted") && d2item.IdentifiedName != "" {
+		itemName = d2item.IdentifiedName
+	} else if name := rareName(affixes); name != "" {
+		// Rare and crafted names come from their two name affixes
+		itemName = name
+	} else if quality == "Magic" && affixes != nil {
+		itemName = magicName(baseName, affixes)
+	}
+
+	// Runewords are named after the runeword, and their socketed runes'
+	// stats are listed separately from the runeword's own
+	sockets := parseSockets(d2item)
+	if sockets.runeword != "" {
+		itemName = sockets.runeword
+	}
+
+	item := &models.Item{
+		Name:          itemName,
+		BaseName:      baseName,
+		Code:          d2item.Desc().Code,
+		UnitID:        int(d2item.UnitID),
+		Type:          getItemType(d2item),
+		Quality:       quality,
+		Properties:    parseProperties(d2item, subtractStats(d2item.Stats, sockets.filler)),
+		Stats:         rawStats(d2item.Stats),
+		Sockets:       len(d2item.Sockets),
+		SocketedItems: sockets.items,
+		Runeword:      sockets.runeword,
+		IsEthereal:    d2item.Ethereal,
+		IsIdentified:  d2item.Identified,
+		Affixes:       affixes,
+		Location:      parseLocation(d2item.Location),
+		Requirements:  parseRequirements(d2item),
+		Defense:       parseDefense(d2item),
+		Damage:        parseDamage(d2item),
+	}
+
+	return item
+}
+
+// parseDefense returns the item's total defense, including enhanced defense
+func parseDefense(d2item *data.Item) int {
+	if s, ok := d2item.FindStat(stat.Defense, 0); ok {
+		return s.Value
+	}
+	return 0
+}
+
+// parseDamage returns the weapon's physical damage range. Two-handed damage
+// wins for weapons that have both (e.g. swords), matching the traded value.
+func parseDamage(d2item *data.Item) *models.DamageRange {
+	pairs := [][2]stat.ID{
+		{stat.TwoHandedMinDamage, stat.TwoHandedMaxDamage},
+		{stat.MinDamage, stat.MaxDamage},
+	}
+
+	for _, pair := range pairs {
+		// Final stats include enhanced damage; fall back to the base roll
+		for _, stats := range []stat.Stats{d2item.Stats, d2item.BaseStats} {
+			minDmg, hasMin := stats.FindStat(pair[0], 0)
+			maxDmg, hasMax := stats.FindStat(pair[1], 0)
+			if hasMin && hasMax && maxDmg.Value > 0 {
+				return &models.DamageRange{
+					Min:  minDmg.Value,
+					Max:  maxDmg.Value,
+					Type: "Physical",
+				}
+			}
+		}
+	}
+
+	// Fall back to the base item description for unmodified weapons
+	desc := d2item.Desc()
+	if desc.TwoHandMaxDamage > 0 {
+		return &models.DamageRange{Min: desc.TwoHandMinDamage, Max: desc.TwoHandMaxDamage, Type: "Physical"}
+	}
+	if desc.MaxDamage > 0 {
+		return &models.DamageRange{Min: desc.MinDamage, Max: desc.MaxDamage, Type: "Physical"}
+	}
+
+	return nil
+}
+
+// parseRequirements returns the level, strength and dexterity needed to equip the item
+func parseRequirements(d2item *data.Item) *models.Requirements {
+	desc := d2item.Desc()
+	reqs := &models.Requirements{
+		Level:     d2item.LevelReq,
+		Strength:  desc.RequiredStrength,
+		Dexterity: desc.RequiredDexterity,
+	}
+
+	// "Requirements -X%" scales strength and dexterity
+	if s, ok := d2item.FindStat(stat.Requirements, 0); ok {
+		reqs.Strength = reqs.Strength * (100 + s.Value) / 100
+		reqs.Dexterity = reqs.Dexterity * (100 + s.Value) / 100
+	}
+
+	// Ethereal items need 10 less strength and dexterity
+	if d2item.Ethereal {
+		reqs.Strength = max(reqs.Strength-10, 0)
+		reqs.Dexterity = max(reqs.Dexterity-10, 0)
+	}
+
+	if reqs.Level == 0 && reqs.Strength == 0 && reqs.Dexterity == 0 {
+		return nil
+	}
+	return reqs
+}
+
+// getItemType determines the item type category
+func getItemType(item *data.Item) string {
+	itemType := item.Type()
+
+	// Return the type name from d2go
+	if itemType.Code != "" {
+		return itemType.Code
+	}
+
+	return string(item.Name)
+}
+
+// parseProperties extracts the item's own properties/stats (without socket
+// filler) and formats them for Traderie
+func parseProperties(item *data.Item, itemStats stat.Stats) []models.Property {
+	properties := statProperties(itemStats)
+
+	// Add socket information if present
+	if len(item.Sockets) > 0 {
+		properties = append(properties, models.Property{
+			Name:  "Sockets",
+			Value: len(item.Sockets),
+		})
+	}
+
+	// Add ethereal if applicable
+	if item.Ethereal {
+		properties = append(properties, models.Property{
+			Name:  "Ethereal",
+			Value: true,
+		})
+	}
+
+	return properties
+}
+
+// statProperties turns a stat list into properties, consolidating all
+// resistances, all attributes and damage ranges
+func statProperties(itemStats stat.Stats) []models.Property {
+	var properties []models.Property
+
+	// Track resistance values for consolidation
+	var fireRes, coldRes, lightRes, poisonRes int
+	var hasFireRes, hasColdRes, hasLightRes, hasPoisonRes bool
+
+	// Track attribute values for consolidation
+	var strength, energy, dexterity, vitality int
+	var hasStrength, hasEnergy, hasDexterity, hasVitality bool
+
+	// Track damage ranges for consolidation
+	var minDmg, maxDmg int
+	var hasMinDmg, hasMaxDmg bool
+
+	// Map to track which stats we've already processed
+	processedStats := make(map[string]bool)
+
+	// First pass: collect resistances, attributes, and damage for consolidation
+	for _, s := range itemStats {
+		switch s.ID {
+		case stat.FireResist:
+			fireRes = s.Value
+			hasFireRes = true
+		case stat.LightningResist:
+			lightRes = s.Value
+			hasLightRes = true
+		case stat.ColdResist:
+			coldRes = s.Value
+			hasColdRes = true
+		case stat.PoisonResist:
+			poisonRes = s.Value
+			hasPoisonRes = true
+		case stat.Strength:
+			strength = s.Value
+			hasStrength = true
+		case stat.Energy:
+			energy = s.Value
+			hasEnergy = true
+		case stat.Dexterity:
+			dexterity = s.Value
+			hasDexterity = true
+		case stat.Vitality:
+			vitality = s.Value
+			hasVitality = true
+		case stat.MinDamage:
+			minDmg = s.Value
+			hasMinDmg = true
+		case stat.MaxDamage:
+			maxDmg = s.Value
+			hasMaxDmg = true
+		}
+	}
+
+	// Check if all resistances are equal (All Res charm)
+	if hasFireRes && hasColdRes && hasLightRes && hasPoisonRes &&
+		fireRes == coldRes && fireRes == lightRes && fireRes == poisonRes {
+		// Consolidate to "to All Resistances" (exact Traderie format)
+		properties = append(properties, models.Property{
+			Name:  "to All Resistances",
+			Value: fireRes,
+		})
+		processedStats["resist_all"] = true
+	}
+
+	// Check if all attributes are equal (All Attributes)
+	if hasStrength && hasEnergy && hasDexterity && hasVitality &&
+		strength == energy && strength == dexterity && strength == vitality {
+		// Consolidate to "to All Attributes"
+		properties = append(properties, models.Property{
+			Name:  "to All Attributes",
+			Value: strength,
+		})
+		processedStats["attr_all"] = true
+	}
+
+	// Consolidate damage range
+	if hasMinDmg && hasMaxDmg {
+		properties = append(properties, models.Property{
+			Name:  "Adds Damage",
+			Value: fmt.Sprintf("%d-%d", minDmg, maxDmg),
+		})
+		processedStats["damage_range"] = true
+	}
+
+	// Elemental damage ranges, one property per element
+	elementalProps, elementalStats := parseElementalDamage(itemStats)
+	properties = append(properties, elementalProps...)
+
+	// Second pass: add remaining stats with proper Traderie naming
+	for _, s := range itemStats {
+		if elementalStats[s.ID] {
+			continue
+		}
+		// Skip if we already consolidated this stat
+		if processedStats["resist_all"] && (s.ID == stat.FireResist || s.ID == stat.LightningResist || s.ID == stat.ColdResist || s.ID == stat.PoisonResist) {
+			continue
+		}
+		if processedStats["attr_all"] && (s.ID == stat.Strength || s.ID == stat.Energy || s.ID == stat.Dexterity || s.ID == stat.Vitality) {
+			continue
+		}
+		if processedStats["damage_range"] && (s.ID == stat.MinDamage || s.ID == stat.MaxDamage) {
+			continue
+		}
+		// Defense is carried on the item itself, see parseDefense
+		if s.ID == stat.Defense {
+			continue
+		}
+
+		entry, _ := stats.Default().Lookup(int(s.ID))
+		switch entry.Encoding {
+		case stats.EncodingSkillChance, stats.EncodingCharges:
+			// Chance-to-cast and charged skills become structured properties
+			properties = append(properties, skillProcProperty(entry, s.Value, s.Layer))
+			continue
+		case stats.EncodingPerLevel:
+			// Stats based on character level carry their level 99 value
+			properties = append(properties, perLevelProperty(entry, s.Value))
+			continue
+		}
+
+		statName, traderieName := mapStatToTraderie(int16(s.ID), s.Value, s.Layer)
+		if statName != "" {
+			properties = append(properties, models.Property{
+				Name:     statName,
+				Value:    s.Value,
+				Traderie: traderieName,
+			})
+		}
+	}
+
+	return properties
+}
+
+// rawStats copies the game's stat list into the model
+func rawStats(itemStats stat.Stats) []models.Stat {
+	raw := make([]models.Stat, 0, len(itemStats))
+	for _, s := range itemStats {
+		raw = append(raw, models.Stat{ID: int(s.ID), Layer: s.Layer, Value: s.Value})
+	}
+	return raw
+}
+
+// mapStatToTraderie names a stat using the stat catalog, decoding the layer
+// for skill bonuses. The second result is the Traderie property when the stat
+// determines it. It returns "" for stats that never show on an item.
+func mapStatToTraderie(statID int16, value int, layer int) (string, string) {
+	entry, ok := stats.Default().Lookup(int(statID))
+	if !ok || entry.Hidden() {
+		return "", ""
+	}
+
+	switch entry.Encoding {
+	case stats.EncodingClassSkills:
+		// +X to Paladin Skills, etc.; the layer is the class
+		return classSkillName(value, layer), ""
+	case stats.EncodingSkill:
+		// The layer is the skill ID
+		switch entry.Key {
+		case "item_nonclassskill":
+			return oskillName(value, layer)
+		case "item_singleskill":
+			return singleSkillName(value, layer)
+		default:
+			return auraName(value, layer), ""
+		}
+	case stats.EncodingSkillTab:
+		return skillTabName(value, layer)
+	case stats.EncodingElementalSkills:
+		return elementalSkillName(value, layer)
+	}
+
+	return entry.Name, entry.Traderie
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package memory
+
+import "fmt"
+
+// unsupportedProcessFinder never finds D2R on platforms without memory reading
+type unsupportedProcessFinder struct{}
+
+// NewProcessFinder returns a finder that never attaches on this platform
+func NewProcessFinder(recordDir string) ProcessFinder {
+	return unsupportedProcessFinder{}
+}
+
+// FindProcesses always fails on this platform
+func (unsupportedProcessFinder) FindProcesses() ([]uint32, error) {
+	return nil, fmt.Errorf("reading D2R memory is only supported on Windows")
+}
+
+// Attach always fails on this platform
+func (unsupportedProcessFinder) Attach(pid uint32) (ItemSource, error) {
+	return nil, fmt.Errorf("reading D2R memory is only supported on Windows")
+}
+
+// IsAlive always reports false on this platform
+func (unsupportedProcessFinder) IsAlive(pid uint32) bool {
+	return false
+}
@@ -0,0 +1,60 @@
+//go:build windows
+
+package memory
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/memory"
+	"golang.org/x/sys/windows"
+)
+
+// stillActive is the exit code Windows reports for a running process
+const stillActive = 259
+
+// gameProcessFinder finds and attaches to D2R through the Windows API
+type gameProcessFinder struct {
+	recordDir string
+}
+
+// NewProcessFinder creates the Windows process finder. Readers it attaches
+// are put in record mode when recordDir is not empty.
+func NewProcessFinder(recordDir string) ProcessFinder {
+	return &gameProcessFinder{recordDir: recordDir}
+}
+
+// FindProcesses returns the PIDs owning a D2R game window
+func (f *gameProcessFinder) FindProcesses() ([]uint32, error) {
+	return findD2RProcesses()
+}
+
+// Attach opens a memory reader on the given process
+func (f *gameProcessFinder) Attach(pid uint32) (ItemSource, error) {
+	process, err := memory.NewProcessForPID(pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open D2R process %d: %w", pid, err)
+	}
+
+	reader := &Reader{
+		gameReader: memory.NewGameReader(process),
+	}
+	if f.recordDir != "" {
+		reader.EnableRecording(f.recordDir)
+	}
+	return reader, nil
+}
+
+// IsAlive reports whether the process is still running
+func (f *gameProcessFinder) IsAlive(pid uint32) bool {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(handle)
+
+	var exitCode uint32
+	if err := windows.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == stillActive
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+)
+
+// statList builds a stat list from id/value pairs
+func statList(pairs ...int) stat.Stats {
+	var stats stat.Stats
+	for i := 0; i+1 < len(pairs); i += 2 {
+		stats = append(stats, stat.Data{ID: stat.ID(pairs[i]), Value: pairs[i+1]})
+	}
+	return stats
+}
+
+// recipeRuneword returns the runeword the rune codes spell in socket order
+// when the item could be one: a normal or superior base of a type the recipe
+// allows. It names runewords the game doesn't name itself.
+func recipeRuneword(data *gamedata.Data, runes []string, quality, itemType string) string {
+	if quality != "Normal" && quality != "Superior" {
+		return ""
+	}
+	rw, ok := data.RunewordByRunes(runes)
+	if !ok {
+		return ""
+	}
+
+	// Base types can only be checked with the item type hierarchy loaded
+	if data.Source(gamedata.FileItemTypes) != "" && !data.MatchesTypes(itemType, rw.Types, rw.ExcludeTypes) {
+		return ""
+	}
+	return rw.Name
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+)
+
+func TestRecipeRuneword(t *testing.T) {
+	data := withTestTables(t)
+	spirit := []string{"r07", "r10", "r09", "r11"}
+
+	tests := []struct {
+		name     string
+		runes    []string
+		quality  string
+		itemType string
+		want     string
+	}{
+		{"sword", spirit, "Normal", "swor", "Spirit"},
+		{"superior shield through its parent type", spirit, "Superior", "shie", "Spirit"},
+		{"base type the recipe doesn't allow", spirit, "Normal", "helm", ""},
+		{"magic base", spirit, "Magic", "swor", ""},
+		{"runes out of order", []string{"r10", "r07", "r09", "r11"}, "Normal", "swor", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recipeRuneword(data, tt.runes, tt.quality, tt.itemType); got != tt.want {
+				t.Errorf("recipeRuneword(%v, %s, %s) = %q, want %q", tt.runes, tt.quality, tt.itemType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSocketsRuneword(t *testing.T) {
+	// The embedded tables, so the recipe fallback checks the base type
+	embedded, err := gamedata.Load(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamedata.SetDefault(embedded)
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+
+	sword := item.GetIDByName("CrystalSword")
+
+	var runes []data.Item
+	for _, name := range []item.Name{"TalRune", "ThulRune", "OrtRune", "AmnRune"} {
+		runes = append(runes, data.Item{ID: item.GetIDByName(string(name)), Name: name})
+	}
+
+	tests := []struct {
+		name   string
+		d2item data.Item
+		want   string
+	}{
+		{
+			name:   "runes in recipe order without the runeword flag",
+			d2item: data.Item{ID: sword, Name: "CrystalSword", Quality: item.QualityNormal, Sockets: runes},
+			want:   "",
+		},
+		{
+			name:   "name read by d2go",
+			d2item: data.Item{ID: sword, Name: "CrystalSword", Quality: item.QualityNormal, Sockets: runes, IsRuneword: true, RunewordName: "Insight"},
+			want:   "Insight",
+		},
+		{
+			name:   "flagged runeword named from its runes",
+			d2item: data.Item{ID: sword, Name: "CrystalSword", Quality: item.QualitySuperior, Sockets: runes, IsRuneword: true},
+			want:   "Spirit",
+		},
+		{
+			name:   "flagged runeword on a magic base",
+			d2item: data.Item{ID: sword, Name: "CrystalSword", Quality: item.QualityMagic, Sockets: runes, IsRuneword: true},
+			want:   "",
+		},
+		{
+			name:   "runes that spell no recipe",
+			d2item: data.Item{ID: sword, Name: "CrystalSword", Quality: item.QualityNormal, Sockets: runes[:3], IsRuneword: true},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSockets(&tt.d2item).runeword; got != tt.want {
+				t.Errorf("runeword = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSocketsFiller(t *testing.T) {
+	embedded, err := gamedata.Load(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamedata.SetDefault(embedded)
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+
+	socket := func(name item.Name) data.Item {
+		return data.Item{ID: item.GetIDByName(string(name)), Name: name}
+	}
+	jewel := socket("Jewel")
+	jewel.Stats = stat.Stats{{ID: stat.FireResist, Value: 15}}
+
+	tests := []struct {
+		name    string
+		base    item.Name
+		sockets []data.Item
+		types   []string
+		filler  stat.Stats
+	}{
+		{
+			name:    "gem in a helm",
+			base:    "Shako",
+			sockets: []data.Item{socket("PerfectRuby")},
+			types:   []string{"gem"},
+			filler:  stat.Stats{{ID: stat.MaxLife, Value: 38}},
+		},
+		{
+			name:    "gem and rune in a weapon",
+			base:    "CrystalSword",
+			sockets: []data.Item{socket("PerfectSkull"), socket("ShaelRune")},
+			types:   []string{"gem", "rune"},
+			filler:  stat.Stats{{ID: stat.LifeSteal, Value: 4}, {ID: stat.ManaSteal, Value: 3}, {ID: stat.IncreasedAttackSpeed, Value: 20}},
+		},
+		{
+			name:    "jewel keeps its own stats",
+			base:    "Monarch",
+			sockets: []data.Item{jewel},
+			types:   []string{"jewel"},
+			filler:  jewel.Stats,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d2item := data.Item{ID: item.GetIDByName(string(tt.base)), Name: tt.base, Sockets: tt.sockets}
+			contents := parseSockets(&d2item)
+
+			var types []string
+			for _, socketed := range contents.items {
+				types = append(types, socketed.Type)
+			}
+			if !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("socketed types = %v, want %v", types, tt.types)
+			}
+			if !reflect.DeepEqual(contents.filler, tt.filler) {
+				t.Errorf("filler = %+v, want %+v", contents.filler, tt.filler)
+			}
+		})
+	}
+}
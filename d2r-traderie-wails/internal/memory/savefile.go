@@ -0,0 +1,342 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// saveSignature starts every .d2s file and every .d2i stash page
+const saveSignature = 0xAA55AA55
+
+// minSaveVersion is the first D2R save version. Older saves store item
+// codes as plain text instead of Huffman codes and aren't supported.
+const minSaveVersion = 0x61
+
+// Offsets into the .d2s header
+const (
+	d2sVersionOffset    = 0x04
+	d2sNameOffset       = 0x14
+	d2sStatusOffset     = 0x24
+	d2sClassOffset      = 0x28
+	d2sLevelOffset      = 0x2B
+	d2sMercOffset       = 0xB3
+	d2sNameOffsetD2R    = 0x12B // Newer D2R saves keep the name here and leave the old field empty
+	d2sAttributesOffset = 0x2FD
+	d2sSkillsLength     = 32 // "if" followed by 30 skill levels
+)
+
+// Character status flags in the .d2s header
+const (
+	statusHardcore  = 0x04
+	statusExpansion = 0x20
+	statusLadder    = 0x40
+)
+
+// d2iPageHeaderLength is the size of a shared stash page header, up to its item list
+const d2iPageHeaderLength = 64
+
+// attributeBits is the size of each character attribute in the "gf" section, by ID
+var attributeBits = map[int]int{
+	0: 10, 1: 10, 2: 10, 3: 10, 4: 10, 5: 8,
+	6: 21, 7: 21, 8: 21, 9: 21, 10: 21, 11: 21,
+	12: 7, 13: 32, 14: 25, 15: 25,
+}
+
+// SaveFile holds the items read from a .d2s character or .d2i shared stash file
+type SaveFile struct {
+	Path      string            `json:"path"`
+	Character *models.Character `json:"character,omitempty"` // Nil for shared stash files
+	Items     []*models.Item    `json:"items"`
+	Warnings  []string          `json:"warnings,omitempty"` // Items that couldn't be read, and why
+}
+
+// LoadSaveFile reads the items of an offline character (.d2s) or shared stash (.d2i)
+func LoadSaveFile(path string) (*SaveFile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save file: %w", err)
+	}
+
+	var save *SaveFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".d2s":
+		save, err = ParseCharacterSave(raw)
+	case ".d2i":
+		save, err = ParseSharedStash(raw)
+	default:
+		return nil, fmt.Errorf("unsupported save file %s: expected .d2s or .d2i", filepath.Base(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	save.Path = path
+	log.Printf("✓ Loaded %d items from %s", len(save.Items), filepath.Base(path))
+	return save, nil
+}
+
+// warn records a problem that left items out of the save
+func (s *SaveFile) warn(format string, args ...interface{}) {
+	warning := fmt.Sprintf(format, args...)
+	log.Printf("⚠️ %s", warning)
+	s.Warnings = append(s.Warnings, warning)
+}
+
+// readItems reads an item list, keeping the items read before any that
+// fails and recording the ones left out. where places the list in warnings,
+// e.g. "on Mulehaven" or "in shared stash tab 2".
+func (s *SaveFile) readItems(r *bitReader, data *gamedata.Data, version int, where string, stashTab int) ([]*models.Item, error) {
+	items, err := readItemList(r, data, version)
+	parsed, unknown := saveModels(data, items, stashTab)
+	if len(unknown) > 0 {
+		s.warn("left out items with unknown codes %s: %s; extract the game's item tables to read them",
+			where, strings.Join(unknown, ", "))
+	}
+
+	var unread *unreadItems
+	if errors.As(err, &unread) {
+		s.warn("%d of %d items %s couldn't be read: %v", unread.total-unread.index, unread.total, where, unread)
+	}
+	return parsed, err
+}
+
+// ParseCharacterSave reads a .d2s file: the character header, then the
+// inventory, stash, cube and equipped items, the corpse and the mercenary's items
+func ParseCharacterSave(raw []byte) (*SaveFile, error) {
+	character, version, err := parseSaveHeader(raw)
+	if err != nil {
+		return nil, err
+	}
+	hasMerc := binary.LittleEndian.Uint32(raw[d2sMercOffset:]) != 0
+
+	r := newBitReader(raw, d2sAttributesOffset)
+	if err := r.expect("gf"); err != nil {
+		return nil, err
+	}
+	if err := skipAttributes(r); err != nil {
+		return nil, err
+	}
+	if err := r.expect("if"); err != nil {
+		return nil, err
+	}
+	r.skip(d2sSkillsLength*8 - 16)
+
+	data := gamedata.Default()
+	save := &SaveFile{Character: character}
+
+	items, err := save.readItems(r, data, version, "on "+character.Name, 0)
+	var unread *unreadItems
+	if err != nil && !errors.As(err, &unread) {
+		return nil, fmt.Errorf("failed to read %s's items: %w", character.Name, err)
+	}
+	save.Items = append(save.Items, items...)
+
+	// The mercenary's items follow the character's, so they can only be
+	// found when every item before them was read
+	mercenary := character.Expansion && hasMerc
+	if err == nil {
+		err = save.readCorpsesAndMercenary(r, data, version, character.Expansion, hasMerc)
+	}
+	if err != nil && mercenary {
+		save.warn("%s's mercenary items weren't read", character.Name)
+	}
+
+	// Only single-player characters have a save file
+	character.Offline = true
+	for _, saved := range save.Items {
+		saved.Character = character
+	}
+	return save, nil
+}
+
+// readCorpsesAndMercenary reads past the corpse item lists and adds the
+// mercenary's items
+func (s *SaveFile) readCorpsesAndMercenary(r *bitReader, data *gamedata.Data, version int, expansion, hasMerc bool) error {
+	// Corpses carry their own item lists; those items aren't tradeable from here
+	if err := r.expect("JM"); err != nil {
+		return err
+	}
+	for corpses := int(r.bits(16)); corpses > 0; corpses-- {
+		r.skip(12 * 8)
+		if _, err := readItemList(r, data, version); err != nil {
+			return fmt.Errorf("failed to read corpse items: %w", err)
+		}
+	}
+
+	if !expansion {
+		return nil
+	}
+	if err := r.expect("jf"); err != nil {
+		return err
+	}
+	if !hasMerc {
+		return nil
+	}
+
+	mercItems, err := s.readItems(r, data, version, "on the mercenary", 0)
+	for _, mercItem := range mercItems {
+		if mercItem.Location != nil {
+			mercItem.Location.Type = string(item.LocationMercenary)
+		}
+		s.Items = append(s.Items, mercItem)
+	}
+
+	// Unread mercenary items are already recorded
+	var unread *unreadItems
+	if errors.As(err, &unread) {
+		return nil
+	}
+	return err
+}
+
+// parseSaveHeader reads the character and save version from a .d2s header
+func parseSaveHeader(raw []byte) (*models.Character, int, error) {
+	if len(raw) < d2sAttributesOffset+2 || binary.LittleEndian.Uint32(raw) != saveSignature {
+		return nil, 0, fmt.Errorf("not a D2R character save")
+	}
+	version := int(binary.LittleEndian.Uint32(raw[d2sVersionOffset:]))
+	if version < minSaveVersion {
+		return nil, 0, fmt.Errorf("unsupported save version %#x: only D2R saves can be read", version)
+	}
+
+	status := raw[d2sStatusOffset]
+	character := &models.Character{
+		Name:       saveString(raw[d2sNameOffset : d2sNameOffset+16]),
+		Level:      int(raw[d2sLevelOffset]),
+		Hardcore:   status&statusHardcore != 0,
+		Expansion:  status&statusExpansion != 0,
+		Ladder:     status&statusLadder != 0,
+		FlagsKnown: true,
+	}
+	if character.Name == "" && len(raw) >= d2sNameOffsetD2R+16 {
+		character.Name = saveString(raw[d2sNameOffsetD2R : d2sNameOffsetD2R+16])
+	}
+	if class := int(raw[d2sClassOffset]); class < len(classNames) {
+		character.Class = classNames[class]
+	}
+	return character, version, nil
+}
+
+// SavesDir returns the folder D2R keeps offline characters and the shared stash in
+func SavesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "Saved Games", "Diablo II Resurrected")
+}
+
+// readLocalSave notes whether dir holds a single-player save with the
+// character's name. Outside online games that save is the character itself,
+// so it's marked offline. When the game process didn't give up the realm
+// flags they're taken from the save header instead.
+func readLocalSave(character *models.Character, dir string, online bool) {
+	if character == nil || character.Name == "" || dir == "" {
+		return
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, character.Name+".d2s"))
+	if err != nil {
+		return
+	}
+	character.LocalSave = true
+	if online {
+		return
+	}
+	character.Offline = true
+	if character.FlagsKnown {
+		return
+	}
+
+	saved, _, err := parseSaveHeader(raw)
+	if err != nil {
+		log.Printf("⚠️ Failed to read the save header of %s: %v", character.Name, err)
+		return
+	}
+	character.Hardcore = saved.Hardcore
+	character.Ladder = saved.Ladder
+	character.Expansion = saved.Expansion
+	character.FlagsKnown = true
+}
+
+// ParseSharedStash reads a .d2i file: a sequence of stash pages, each a
+// 64-byte header followed by an item list. Pages carry their size, so a tab
+// with unreadable items doesn't keep the others from being read.
+func ParseSharedStash(raw []byte) (*SaveFile, error) {
+	data := gamedata.Default()
+	save := &SaveFile{}
+
+	for offset, tab := 0, 1; offset+d2iPageHeaderLength <= len(raw); tab++ {
+		var err error
+		if binary.LittleEndian.Uint32(raw[offset:]) != saveSignature {
+			err = fmt.Errorf("not a D2R shared stash: bad page header at offset %d", offset)
+		}
+		version := int(binary.LittleEndian.Uint32(raw[offset+8:]))
+		size := int(binary.LittleEndian.Uint32(raw[offset+16:]))
+		if err == nil && (size < d2iPageHeaderLength || offset+size > len(raw)) {
+			err = fmt.Errorf("shared stash page %d has a bad size %d", tab, size)
+		}
+		if err != nil {
+			if tab == 1 {
+				return nil, err
+			}
+			// Without a page size the following tabs can't be found
+			save.warn("tabs %d and up weren't read: %v", tab, err)
+			break
+		}
+
+		r := newBitReader(raw[:offset+size], offset+d2iPageHeaderLength)
+		items, err := save.readItems(r, data, version, fmt.Sprintf("in shared stash tab %d", tab), tab)
+		save.Items = append(save.Items, items...)
+		var unread *unreadItems
+		if err != nil && !errors.As(err, &unread) {
+			save.warn("shared stash tab %d couldn't be read: %v", tab, err)
+		}
+
+		offset += size
+	}
+
+	if len(save.Items) == 0 && len(raw) < d2iPageHeaderLength {
+		return nil, fmt.Errorf("not a D2R shared stash")
+	}
+	return save, nil
+}
+
+// skipAttributes reads past the character attributes: 9-bit IDs, each
+// followed by a value of its own width, ending with 0x1FF
+func skipAttributes(r *bitReader) error {
+	for {
+		id := int(r.bits(9))
+		if id == 0x1FF {
+			break
+		}
+		size, ok := attributeBits[id]
+		if !ok {
+			return fmt.Errorf("unknown character attribute %d", id)
+		}
+		r.skip(size)
+		if r.overrun {
+			return errTruncated
+		}
+	}
+	r.align()
+	return nil
+}
+
+// saveString decodes a NUL-padded name field
+func saveString(field []byte) string {
+	if i := bytes.IndexByte(field, 0); i >= 0 {
+		field = field[:i]
+	}
+	return string(field)
+}
@@ -0,0 +1,612 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// testSaveVersion is the save version the test saves are written with
+const testSaveVersion = 0x63
+
+// withTestTables installs the trimmed tables in testdata/excel for the duration of a test
+func withTestTables(t *testing.T) *gamedata.Data {
+	t.Helper()
+	data, err := gamedata.Load(filepath.Join("testdata", "excel"))
+	if err != nil {
+		t.Fatalf("failed to load test tables: %v", err)
+	}
+	gamedata.SetDefault(data)
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+	return data
+}
+
+// bitWriter packs bit fields the way bitReader reads them
+type bitWriter struct {
+	data []byte
+	pos  int
+}
+
+func (w *bitWriter) bits(v uint64, n int) {
+	for i := 0; i < n; i++ {
+		if w.pos>>3 >= len(w.data) {
+			w.data = append(w.data, 0)
+		}
+		w.data[w.pos>>3] |= byte(v>>i&1) << (w.pos & 7)
+		w.pos++
+	}
+}
+
+func (w *bitWriter) align() {
+	w.pos = (w.pos + 7) &^ 7
+	for len(w.data) < w.pos>>3 {
+		w.data = append(w.data, 0)
+	}
+}
+
+func (w *bitWriter) marker(m string) {
+	w.align()
+	for i := 0; i < len(m); i++ {
+		w.bits(uint64(m[i]), 8)
+	}
+}
+
+func (w *bitWriter) itemCode(code string) {
+	code = (code + "    ")[:4]
+	for i := 0; i < len(code); i++ {
+		for path, c := range itemCodeBits {
+			if c != code[i] {
+				continue
+			}
+			for _, b := range path {
+				w.bits(uint64(b-'0'), 1)
+			}
+		}
+	}
+}
+
+// statList writes stats with their ItemStatCost widths. Stats missing from
+// the tables are written as a bare ID, which is as far as a reader gets.
+func (w *bitWriter) statList(data *gamedata.Data, list stat.Stats) {
+	for i := 0; i < len(list); {
+		id := int(list[i].ID)
+		w.bits(uint64(id), 9)
+
+		count := max(statValueCounts[id], 1)
+		for _, s := range list[i : i+count] {
+			sc, ok := data.Stat(int(s.ID))
+			if !ok {
+				return
+			}
+			w.bits(uint64(s.Layer), sc.SaveParamBits)
+			w.bits(uint64(s.Value+sc.SaveAdd), sc.SaveBits)
+		}
+		i += count
+	}
+	w.bits(0x1FF, 9)
+}
+
+// testItem describes an item to write into a test save
+type testItem struct {
+	code       string
+	simple     bool
+	ethereal   bool
+	runewordID int // Non-zero for runewords
+	location   int
+	equipped   int
+	column     int
+	row        int
+	storage    int
+
+	id         uint32
+	level      int
+	quality    int
+	affixes    [2]int // Magic prefix and suffix
+	uniqueID   int
+	setID      int
+	defense    int
+	durability int
+	quantity   int
+	sockets    int
+	stats      stat.Stats
+	rwStats    stat.Stats
+	realmData  bool
+	socketedIn []testItem
+}
+
+// item writes an item and the items socketed in it
+func (w *bitWriter) item(data *gamedata.Data, it testItem) {
+	w.align()
+	bit := func(set bool) {
+		if set {
+			w.bits(1, 1)
+		} else {
+			w.bits(0, 1)
+		}
+	}
+
+	w.bits(0, 4)
+	bit(true) // Identified
+	w.bits(0, 6)
+	bit(it.sockets > 0)
+	w.bits(0, 4)
+	bit(false) // Ear
+	w.bits(0, 4)
+	bit(it.simple)
+	bit(it.ethereal)
+	w.bits(0, 1)
+	bit(false) // Personalized
+	w.bits(0, 1)
+	bit(it.runewordID != 0)
+	w.bits(0, 5)
+
+	w.bits(5, 3)
+	w.bits(uint64(it.location), 3)
+	w.bits(uint64(it.equipped), 4)
+	w.bits(uint64(it.column), 4)
+	w.bits(uint64(it.row), 4)
+	w.bits(uint64(it.storage), 3)
+	w.itemCode(it.code)
+
+	if it.simple {
+		w.bits(uint64(len(it.socketedIn)), 1)
+	} else {
+		w.bits(uint64(len(it.socketedIn)), 3)
+		w.extended(data, it)
+	}
+
+	for _, child := range it.socketedIn {
+		w.item(data, child)
+	}
+}
+
+func (w *bitWriter) extended(data *gamedata.Data, it testItem) {
+	w.bits(uint64(it.id), 32)
+	w.bits(uint64(it.level), 7)
+	w.bits(uint64(it.quality), 4)
+	w.bits(0, 1) // Picture variant
+	w.bits(0, 1) // Class-specific affix
+
+	switch it.quality {
+	case saveQualityLow, saveQualitySuperior:
+		w.bits(0, 3)
+	case saveQualityMagic:
+		w.bits(uint64(it.affixes[0]), 11)
+		w.bits(uint64(it.affixes[1]), 11)
+	case saveQualitySet:
+		w.bits(uint64(it.setID), 12)
+	case saveQualityUnique:
+		w.bits(uint64(it.uniqueID), 12)
+	}
+	if it.runewordID != 0 {
+		w.bits(uint64(it.runewordID), 12)
+		w.bits(5, 4)
+	}
+	if it.realmData {
+		w.bits(1, 1)
+		// All set, so a reader that doesn't skip them misreads the item
+		for i := 0; i < saveRealmDataBits/32; i++ {
+			w.bits(0xFFFFFFFF, 32)
+		}
+	} else {
+		w.bits(0, 1)
+	}
+
+	base, _ := data.Base(it.code)
+	if base.Kind == "armor" {
+		sc, _ := data.Stat(int(stat.Defense))
+		w.bits(uint64(it.defense+sc.SaveAdd), sc.SaveBits)
+	}
+	if base.Kind == "armor" || base.Kind == "weapon" {
+		w.bits(uint64(it.durability), 8)
+		if it.durability > 0 {
+			w.bits(uint64(it.durability), 9)
+		}
+	}
+	if base.Stackable {
+		w.bits(uint64(it.quantity), 9)
+	}
+	if it.sockets > 0 {
+		w.bits(uint64(it.sockets), 4)
+	}
+	if it.quality == saveQualitySet {
+		w.bits(0, 5) // No partial set bonus lists
+	}
+
+	w.statList(data, it.stats)
+	if it.runewordID != 0 {
+		w.statList(data, it.rwStats)
+	}
+}
+
+// itemList writes a "JM" item list
+func (w *bitWriter) itemList(data *gamedata.Data, items ...testItem) {
+	w.marker("JM")
+	w.bits(uint64(len(items)), 16)
+	for _, it := range items {
+		w.item(data, it)
+	}
+}
+
+// characterSave builds a .d2s for an expansion character with a mercenary
+func characterSave(data *gamedata.Data, name string, items, mercItems []testItem) []byte {
+	header := make([]byte, d2sAttributesOffset)
+	binary.LittleEndian.PutUint32(header, saveSignature)
+	binary.LittleEndian.PutUint32(header[d2sVersionOffset:], testSaveVersion)
+	copy(header[d2sNameOffset:], name)
+	header[d2sStatusOffset] = statusExpansion | statusLadder
+	header[d2sClassOffset] = 1 // Sorceress
+	header[d2sLevelOffset] = 85
+	binary.LittleEndian.PutUint32(header[d2sMercOffset:], 0x1234)
+
+	w := &bitWriter{data: header, pos: len(header) * 8}
+	w.marker("gf")
+	w.bits(0, 9) // Strength
+	w.bits(156, 10)
+	w.bits(0x1FF, 9)
+	w.marker("if")
+	w.align()
+	w.bits(0, (d2sSkillsLength-2)*8)
+
+	w.itemList(data, items...)
+	w.marker("JM")
+	w.bits(0, 16) // No corpse
+	w.marker("jf")
+	w.itemList(data, mercItems...)
+	w.marker("kf")
+	return w.data
+}
+
+// stashPage builds one page of a .d2i shared stash
+func stashPage(data *gamedata.Data, items ...testItem) []byte {
+	w := &bitWriter{data: make([]byte, d2iPageHeaderLength), pos: d2iPageHeaderLength * 8}
+	w.itemList(data, items...)
+	w.align()
+
+	binary.LittleEndian.PutUint32(w.data, saveSignature)
+	binary.LittleEndian.PutUint32(w.data[8:], testSaveVersion)
+	binary.LittleEndian.PutUint32(w.data[16:], uint32(len(w.data)))
+	return w.data
+}
+
+// Items used across the save tests
+var (
+	testShako = testItem{
+		code: "uap", location: saveLocationEquipped, equipped: 1,
+		id: 0x51A0, level: 87, quality: saveQualityUnique, uniqueID: 224,
+		defense: 111, durability: 12,
+		stats: stat.Stats{
+			{ID: 16, Value: 141},
+			{ID: 80, Value: 50},
+			{ID: 127, Value: 2},
+			{ID: 0, Value: 2},
+			{ID: 7, Value: 1},
+		},
+	}
+	testSpirit = testItem{
+		code: "crs", location: saveLocationStored, storage: saveStorageStash,
+		id: 0x7E11, level: 30, quality: 2, runewordID: 9,
+		durability: 20, sockets: 4,
+		rwStats: stat.Stats{
+			{ID: 105, Value: 35},
+			{ID: 9, Value: 112},
+		},
+		socketedIn: []testItem{
+			{code: "r07", simple: true, location: 6},
+			{code: "r10", simple: true, location: 6},
+			{code: "r09", simple: true, location: 6},
+			{code: "r11", simple: true, location: 6},
+		},
+	}
+	testCharm = testItem{
+		code: "cm1", location: saveLocationStored, storage: saveStorageInventory,
+		id: 0x0C4A, level: 62, quality: saveQualityMagic, affixes: [2]int{1, 1},
+		stats: stat.Stats{
+			{ID: 39, Value: 5},
+			{ID: 41, Value: 5},
+			{ID: 43, Value: 5},
+			{ID: 45, Value: 5},
+			{ID: 7, Value: 20},
+		},
+	}
+	testSigon = testItem{
+		code: "ghm", location: saveLocationEquipped, equipped: 1,
+		id: 0x2200, level: 12, quality: saveQualitySet, setID: 74,
+		defense: 33, durability: 40,
+		stats: stat.Stats{
+			{ID: 31, Value: 25},
+		},
+	}
+	testRune = testItem{code: "r09", simple: true, location: saveLocationStored, storage: saveStorageStash}
+
+	// testUnknownCode has a base the tables don't list, so its layout is unknown
+	testUnknownCode = testItem{
+		code: "zzz", location: saveLocationStored, storage: saveStorageStash,
+		id: 0x0BAD, level: 40, quality: saveQualityMagic,
+	}
+)
+
+// checkGolden compares a save file with testdata/name, or rewrites it with -update
+func checkGolden(t *testing.T, name string, save *SaveFile) {
+	t.Helper()
+	got, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the parsed save; run with -update and review the diff\ngot:\n%s", path, got)
+	}
+}
+
+func TestParseCharacterSaveGolden(t *testing.T) {
+	data := withTestTables(t)
+	raw := characterSave(data, "Mulehaven", []testItem{testShako, testSpirit, testCharm}, []testItem{testSigon})
+
+	save, err := ParseCharacterSave(raw)
+	if err != nil {
+		t.Fatalf("ParseCharacterSave: %v", err)
+	}
+	checkGolden(t, "character.d2s.golden.json", save)
+}
+
+func TestParseSharedStashGolden(t *testing.T) {
+	data := withTestTables(t)
+
+	// The second tab has an item with a stat the tables don't list; its
+	// first item and the other tabs are still read
+	unknownStat := testCharm
+	unknownStat.stats = stat.Stats{{ID: 300, Value: 1}}
+
+	var raw []byte
+	raw = append(raw, stashPage(data, testRune, testCharm)...)
+	raw = append(raw, stashPage(data, testSpirit, unknownStat, testShako)...)
+	raw = append(raw, stashPage(data, testSigon)...)
+
+	save, err := ParseSharedStash(raw)
+	if err != nil {
+		t.Fatalf("ParseSharedStash: %v", err)
+	}
+	checkGolden(t, "shared.d2i.golden.json", save)
+}
+
+// The fixtures in testdata are synthetic: they were built with the layout
+// this parser reads, not written by the game, so they can't catch a layout
+// the parser gets wrong. They carry a header size and checksum,
+// Mulehaven.d2s keeps its name where newer saves do, and both hold items
+// with realm data and items at different grid places.
+func TestLoadSaveFileFixtures(t *testing.T) {
+	withTestTables(t)
+
+	for _, name := range []string{"Mulehaven.d2s", "SharedStashSoftCoreV2.d2i"} {
+		t.Run(name, func(t *testing.T) {
+			save, err := LoadSaveFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatalf("LoadSaveFile: %v", err)
+			}
+			if len(save.Warnings) > 0 {
+				t.Errorf("warnings: %q, want none", save.Warnings)
+			}
+			save.Path = filepath.Base(save.Path)
+			checkGolden(t, name+".golden.json", save)
+		})
+	}
+}
+
+func TestParseItemWithRealmData(t *testing.T) {
+	data := withTestTables(t)
+	shako := testShako
+	shako.realmData = true
+
+	save, err := ParseCharacterSave(characterSave(data, "Mulehaven", []testItem{shako, testCharm}, nil))
+	if err != nil {
+		t.Fatalf("ParseCharacterSave: %v", err)
+	}
+	if got, want := itemNames(save), []string{"Harlequin Crest", "Shimmering Small Charm of Life"}; !reflect.DeepEqual(got, want) || len(save.Warnings) > 0 {
+		t.Errorf("items = %q with warnings %q, want %q read past the realm data", got, save.Warnings, want)
+	}
+}
+
+func TestParseCharacterSaveSkipsUnreadableItems(t *testing.T) {
+	data := withTestTables(t)
+	unknownSimple := testRune
+	unknownSimple.code = "zz"
+
+	raw := characterSave(data, "Mulehaven", []testItem{testShako, unknownSimple, testUnknownCode, testCharm}, []testItem{testSigon})
+	save, err := ParseCharacterSave(raw)
+	if err != nil {
+		t.Fatalf("ParseCharacterSave: %v", err)
+	}
+
+	if len(save.Items) != 1 || save.Items[0].Name != "Harlequin Crest" {
+		t.Errorf("kept items = %v, want only Harlequin Crest", itemNames(save))
+	}
+
+	want := []string{
+		`left out items with unknown codes on Mulehaven: zz; extract the game's item tables to read them`,
+		`2 of 4 items on Mulehaven couldn't be read: item 3 of 4: unknown item code "zzz"; extract the game's item tables to read it`,
+		`Mulehaven's mercenary items weren't read`,
+	}
+	if strings.Join(save.Warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings:\n%s\nwant:\n%s", strings.Join(save.Warnings, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseSaveErrors(t *testing.T) {
+	data := withTestTables(t)
+
+	if _, err := ParseCharacterSave([]byte("not a save")); err == nil {
+		t.Error("ParseCharacterSave accepted a file without a save header")
+	}
+
+	page := stashPage(data, testRune)
+	binary.LittleEndian.PutUint32(page, 0)
+	if _, err := ParseSharedStash(page); err == nil {
+		t.Error("ParseSharedStash accepted a page without a signature")
+	}
+
+	// A bad header after the first page stops reading but keeps the earlier tabs
+	bad := stashPage(data, testSigon)
+	binary.LittleEndian.PutUint32(bad[16:], 1)
+	save, err := ParseSharedStash(append(stashPage(data, testRune), bad...))
+	if err != nil {
+		t.Fatalf("ParseSharedStash: %v", err)
+	}
+	if len(save.Items) != 1 || len(save.Warnings) != 1 {
+		t.Errorf("got %d items and warnings %q, want 1 item and 1 warning", len(save.Items), save.Warnings)
+	}
+}
+
+func TestReadLocalSave(t *testing.T) {
+	data := withTestTables(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Mulehaven.d2s"), characterSave(data, "Mulehaven", nil, nil), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		known  bool // Flags already read from the game
+		online bool
+		want   models.Character
+	}{
+		{
+			name: "Mulehaven",
+			want: models.Character{Name: "Mulehaven", Ladder: true, Expansion: true, FlagsKnown: true, Offline: true, LocalSave: true},
+		},
+		{
+			name:  "Mulehaven",
+			known: true,
+			want:  models.Character{Name: "Mulehaven", Hardcore: true, FlagsKnown: true, Offline: true, LocalSave: true},
+		},
+		{
+			name:   "Mulehaven",
+			online: true,
+			want:   models.Character{Name: "Mulehaven", LocalSave: true},
+		},
+		{
+			name: "Tradebot",
+			want: models.Character{Name: "Tradebot"},
+		},
+	}
+
+	for _, tt := range tests {
+		character := &models.Character{Name: tt.name}
+		if tt.known {
+			character.Hardcore, character.FlagsKnown = true, true
+		}
+		readLocalSave(character, dir, tt.online)
+		if *character != tt.want {
+			t.Errorf("readLocalSave(%s, online %v) = %+v, want %+v", tt.name, tt.online, *character, tt.want)
+		}
+	}
+}
+
+func TestSaveRunewordName(t *testing.T) {
+	runes := []*saveItem{{code: "r07"}, {code: "r10"}, {code: "r09"}, {code: "r11"}}
+
+	t.Run("from the runes", func(t *testing.T) {
+		data := withTestTables(t)
+		it := &saveItem{code: "crs", quality: 2, runeword: true, socketedIn: runes}
+		if got := it.model(data, 0).Runeword; got != "Spirit" {
+			t.Errorf("runeword = %q, want Spirit", got)
+		}
+	})
+
+	t.Run("without readable runes", func(t *testing.T) {
+		// Runewords aren't named by the ID the save stores
+		data := withTestTables(t)
+		it := &saveItem{code: "crs", quality: 2, runeword: true}
+		if got := it.model(data, 0).Runeword; got != "" {
+			t.Errorf("runeword = %q, want none", got)
+		}
+	})
+
+	t.Run("not a runeword", func(t *testing.T) {
+		data := withTestTables(t)
+		it := &saveItem{code: "crs", quality: 2, socketedIn: runes}
+		if got := it.model(data, 0).Runeword; got != "" {
+			t.Errorf("runeword = %q, want none", got)
+		}
+	})
+}
+
+func TestBitReader(t *testing.T) {
+	r := newBitReader([]byte{0b10110100, 0b00001111}, 0)
+	if got := r.bits(3); got != 0b100 {
+		t.Errorf("bits(3) = %b, want 100", got)
+	}
+	if got := r.bits(9); got != 0b111110110 {
+		t.Errorf("bits(9) across a byte = %b, want 111110110", got)
+	}
+	r.align()
+	if r.pos != 16 {
+		t.Errorf("align moved to bit %d, want 16", r.pos)
+	}
+	if got := r.bits(1); got != 0 || r.err() != errTruncated {
+		t.Errorf("read past the end = %d, %v, want 0, %v", got, r.err(), errTruncated)
+	}
+
+	r = newBitReader([]byte{0xFF, 'J', 'M', 'g'}, 0)
+	r.skip(3)
+	if err := r.expect("JM"); err != nil {
+		t.Errorf("expect after align: %v", err)
+	}
+	if err := r.expect("gf"); !errors.Is(err, errTruncated) {
+		t.Errorf("expect past the end = %v, want %v", err, errTruncated)
+	}
+
+	r = newBitReader([]byte("if"), 0)
+	if err := r.expect("gf"); err == nil || !strings.Contains(err.Error(), `found "if"`) {
+		t.Errorf("expect on the wrong marker = %v", err)
+	}
+}
+
+func TestReadItemCode(t *testing.T) {
+	for _, code := range []string{"r07", "uap", "cm1", "crs", "box", "jaq", "7s8"} {
+		w := &bitWriter{}
+		w.itemCode(code)
+		got, err := newBitReader(w.data, 0).readItemCode()
+		if err != nil || got != code {
+			t.Errorf("readItemCode = %q, %v, want %q", got, err, code)
+		}
+	}
+
+	if _, err := newBitReader(nil, 0).readItemCode(); err == nil {
+		t.Error("readItemCode read a code from no data")
+	}
+}
+
+func itemNames(save *SaveFile) []string {
+	var names []string
+	for _, it := range save.Items {
+		names = append(names, it.Name)
+	}
+	return names
+}
@@ -0,0 +1,691 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// errTruncated is returned when a save file ends in the middle of a record
+var errTruncated = errors.New("save file is truncated")
+
+// bitReader reads the little-endian bit fields D2R packs items into
+type bitReader struct {
+	data    []byte
+	pos     int  // Position in bits
+	overrun bool // Set once a read runs past the end
+}
+
+func newBitReader(data []byte, offset int) *bitReader {
+	return &bitReader{data: data, pos: offset * 8}
+}
+
+// bits reads an n-bit unsigned value, least significant bit first
+func (r *bitReader) bits(n int) uint64 {
+	var v uint64
+	for i := 0; i < n; i++ {
+		byteIndex := r.pos >> 3
+		if byteIndex >= len(r.data) {
+			r.overrun = true
+			return 0
+		}
+		v |= uint64(r.data[byteIndex]>>(r.pos&7)&1) << i
+		r.pos++
+	}
+	return v
+}
+
+// skip moves past n bits
+func (r *bitReader) skip(n int) {
+	r.pos += n
+	if r.pos > len(r.data)*8 {
+		r.overrun = true
+	}
+}
+
+// align moves to the start of the next byte; every item and section starts on one
+func (r *bitReader) align() {
+	r.pos = (r.pos + 7) &^ 7
+}
+
+// expect reads a two-byte section marker like "JM" or "gf"
+func (r *bitReader) expect(marker string) error {
+	r.align()
+	got := []byte{byte(r.bits(8)), byte(r.bits(8))}
+	if r.overrun {
+		return errTruncated
+	}
+	if string(got) != marker {
+		return fmt.Errorf("expected %q section at offset %d, found %q", marker, r.pos/8-2, got)
+	}
+	return nil
+}
+
+// itemCodeBits are the Huffman codes D2R stores item code characters as,
+// written as the bits in the order they are read
+var itemCodeBits = map[string]byte{
+	"10": ' ', "11111011": '0', "1111100": '1', "001100": '2', "1101101": '3',
+	"11111010": '4', "00010110": '5', "1101111": '6', "01111": '7', "000100": '8',
+	"01110": '9', "11110": 'a', "0101": 'b', "01000": 'c', "110001": 'd',
+	"110000": 'e', "010011": 'f', "11010": 'g', "00011": 'h', "1111110": 'i',
+	"000101110": 'j', "010010": 'k', "11101": 'l', "01101": 'm', "001101": 'n',
+	"1111111": 'o', "11001": 'p', "11011001": 'q', "11100": 'r', "00100": 's',
+	"01100": 't', "00001": 'u', "1101110": 'v', "00000": 'w', "00111": 'x',
+	"0001010": 'y', "11011000": 'z',
+}
+
+// readItemCode reads a Huffman-coded item code: four characters, space padded
+func (r *bitReader) readItemCode() (string, error) {
+	var code strings.Builder
+	for i := 0; i < 4; i++ {
+		var path strings.Builder
+		for {
+			if r.bits(1) == 1 {
+				path.WriteByte('1')
+			} else {
+				path.WriteByte('0')
+			}
+			if c, ok := itemCodeBits[path.String()]; ok {
+				code.WriteByte(c)
+				break
+			}
+			if path.Len() > 9 || r.overrun {
+				return "", fmt.Errorf("bad item code at bit %d", r.pos)
+			}
+		}
+	}
+	return strings.TrimSpace(code.String()), nil
+}
+
+// Item qualities as the save stores them
+const (
+	saveQualityLow      = 1
+	saveQualitySuperior = 3
+	saveQualityMagic    = 4
+	saveQualitySet      = 5
+	saveQualityRare     = 6
+	saveQualityUnique   = 7
+	saveQualityCrafted  = 8
+)
+
+// saveQualityNames names save qualities like the memory reader does
+var saveQualityNames = map[int]string{
+	1: "LowQuality", 2: "Normal", 3: "Superior", 4: "Magic",
+	5: "Set", 6: "Rare", 7: "Unique", 8: "Crafted",
+}
+
+// Item locations and storage panels as the save stores them
+const (
+	saveLocationStored   = 0
+	saveLocationEquipped = 1
+	saveLocationBelt     = 2
+	saveLocationCursor   = 4
+	saveStorageInventory = 1
+	saveStorageCube      = 4
+	saveStorageStash     = 5
+)
+
+// saveBodySlots names the equipment slots by their ID in the save
+var saveBodySlots = map[int]string{
+	1: "head", 2: "neck", 3: "torso", 4: "right_arm", 5: "left_arm", 6: "right_ring",
+	7: "left_ring", 8: "belt", 9: "feet", 10: "gloves", 11: "right_arm_secondary", 12: "left_arm_secondary",
+}
+
+// saveRealmDataBits is the size of the realm data that follows the realm
+// data flag of an item: three 32-bit values. Neither the flag nor the size
+// is confirmed against a real save.
+const saveRealmDataBits = 96
+
+// statValueCounts lists stats saved together with the stats that follow
+// them under a single ID, e.g. fire min damage followed by fire max damage
+var statValueCounts = map[int]int{17: 2, 48: 2, 50: 2, 52: 2, 54: 3, 57: 3}
+
+// saveItem is an item as it is packed in a save file
+type saveItem struct {
+	code       string
+	identified bool
+	socketed   bool
+	simple     bool
+	ethereal   bool
+	runeword   bool
+
+	location int
+	equipped int
+	column   int
+	row      int
+	storage  int
+
+	id         uint32 // The item's saved ID, kept when it moves between containers
+	level      int
+	quality    int
+	prefixes   []int
+	suffixes   []int
+	rareNames  [2]int
+	uniqueID   int
+	setID      int
+	defense    int
+	sockets    int
+	stats      stat.Stats // The item's own stats
+	rwStats    stat.Stats // Stats the runeword adds
+	socketedIn []*saveItem
+}
+
+// unreadItems is returned by readItemList along with the items read before
+// one failed. Items are packed without their length, so the ones after it are lost too.
+type unreadItems struct {
+	index int // The item that failed, counting from 0
+	total int
+	err   error
+}
+
+func (e *unreadItems) Error() string {
+	return fmt.Sprintf("item %d of %d: %v", e.index+1, e.total, e.err)
+}
+
+func (e *unreadItems) Unwrap() error {
+	return e.err
+}
+
+// readItemList reads a "JM" item list: a count of top-level items, each
+// followed by the items socketed in it
+func readItemList(r *bitReader, data *gamedata.Data, version int) ([]*saveItem, error) {
+	if err := r.expect("JM"); err != nil {
+		return nil, err
+	}
+
+	count := int(r.bits(16))
+	items := make([]*saveItem, 0, count)
+	for i := 0; i < count; i++ {
+		it, err := readSaveItem(r, data, version)
+		if err != nil {
+			return items, &unreadItems{index: i, total: count, err: err}
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// readSaveItem reads one item and the items socketed in it
+func readSaveItem(r *bitReader, data *gamedata.Data, version int) (*saveItem, error) {
+	r.align()
+	it := &saveItem{}
+
+	// Flags
+	r.skip(4)
+	it.identified = r.bits(1) == 1
+	r.skip(6)
+	it.socketed = r.bits(1) == 1
+	r.skip(4)
+	ear := r.bits(1) == 1
+	r.skip(4)
+	it.simple = r.bits(1) == 1
+	it.ethereal = r.bits(1) == 1
+	r.skip(1)
+	personalized := r.bits(1) == 1
+	r.skip(1)
+	it.runeword = r.bits(1) == 1
+	r.skip(5)
+
+	r.skip(3) // Item format version; the width isn't confirmed against a real save
+	it.location = int(r.bits(3))
+	it.equipped = int(r.bits(4))
+	it.column = int(r.bits(4))
+	it.row = int(r.bits(4))
+	it.storage = int(r.bits(3))
+
+	if ear {
+		// Ears hold the victim's class, level and name, and nothing we trade on
+		it.code = "ear"
+		r.skip(3 + 7)
+		skipSaveName(r, version)
+		r.align()
+		return it, r.err()
+	}
+
+	var err error
+	if it.code, err = r.readItemCode(); err != nil {
+		return nil, err
+	}
+
+	filled := 0
+	if it.simple {
+		filled = int(r.bits(1))
+	} else {
+		filled = int(r.bits(3))
+		if err := it.readExtended(r, data, version, personalized); err != nil {
+			return nil, err
+		}
+	}
+	r.align()
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < filled; i++ {
+		child, err := readSaveItem(r, data, version)
+		if err != nil {
+			return nil, fmt.Errorf("socketed item %d of %s: %w", i+1, it.code, err)
+		}
+		it.socketedIn = append(it.socketedIn, child)
+	}
+	return it, nil
+}
+
+// readExtended reads the part of an item that simple items like runes and gems leave out
+func (it *saveItem) readExtended(r *bitReader, data *gamedata.Data, version int, personalized bool) error {
+	it.id = uint32(r.bits(32))
+	it.level = int(r.bits(7))
+	it.quality = int(r.bits(4))
+	if r.bits(1) == 1 {
+		r.skip(3) // Picture variant, e.g. for rings and charms
+	}
+	if r.bits(1) == 1 {
+		r.skip(11) // Class-specific automatic affix
+	}
+
+	switch it.quality {
+	case saveQualityLow, saveQualitySuperior:
+		r.skip(3)
+	case saveQualityMagic:
+		it.prefixes = []int{int(r.bits(11))}
+		it.suffixes = []int{int(r.bits(11))}
+	case saveQualitySet:
+		it.setID = int(r.bits(12))
+	case saveQualityUnique:
+		it.uniqueID = int(r.bits(12))
+	case saveQualityRare, saveQualityCrafted:
+		it.rareNames = [2]int{int(r.bits(8)), int(r.bits(8))}
+		// Up to three prefixes and three suffixes, alternating
+		for i := 0; i < 6; i++ {
+			if r.bits(1) == 0 {
+				continue
+			}
+			if id := int(r.bits(11)); i%2 == 0 {
+				it.prefixes = append(it.prefixes, id)
+			} else {
+				it.suffixes = append(it.suffixes, id)
+			}
+		}
+	}
+
+	if it.runeword {
+		r.skip(16) // Runeword ID; runewords are named from their runes instead
+	}
+	if personalized {
+		skipSaveName(r, version)
+	}
+	if it.code == "tbk" || it.code == "ibk" {
+		r.skip(5) // Tome spell
+	}
+	if r.bits(1) == 1 {
+		r.skip(saveRealmDataBits)
+	}
+
+	base, ok := data.Base(it.code)
+	if !ok {
+		return fmt.Errorf("unknown item code %q; extract the game's item tables to read it", it.code)
+	}
+
+	if base.Kind == "armor" {
+		v, err := readSaveStat(r, data, int(stat.Defense))
+		if err != nil {
+			return err
+		}
+		it.defense = v
+	}
+	if base.Kind == "armor" || base.Kind == "weapon" {
+		maxDurability, err := readSaveStat(r, data, 73)
+		if err != nil {
+			return err
+		}
+		// Indestructible items store no current durability
+		if maxDurability > 0 {
+			if _, err := readSaveStat(r, data, 72); err != nil {
+				return err
+			}
+		}
+	}
+	if base.Stackable {
+		r.skip(9) // Quantity; the width isn't confirmed against a real save
+	}
+	if it.socketed {
+		it.sockets = int(r.bits(4))
+	}
+
+	// Set items flag which of their five partial set bonus lists follow
+	setLists := 0
+	if it.quality == saveQualitySet {
+		setLists = int(r.bits(5))
+	}
+
+	var err error
+	if it.stats, err = readStatList(r, data); err != nil {
+		return err
+	}
+	for ; setLists > 0; setLists >>= 1 {
+		if setLists&1 == 1 {
+			// Set bonuses only apply with other set pieces worn; they aren't the item's own stats
+			if _, err := readStatList(r, data); err != nil {
+				return err
+			}
+		}
+	}
+	if it.runeword {
+		if it.rwStats, err = readStatList(r, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSaveStat reads a value stored with a stat's ItemStatCost save bits
+func readSaveStat(r *bitReader, data *gamedata.Data, id int) (int, error) {
+	sc, ok := data.Stat(id)
+	if !ok || sc.SaveBits == 0 {
+		return 0, fmt.Errorf("stat %d has no save bits in ItemStatCost", id)
+	}
+	return int(r.bits(sc.SaveBits)) - sc.SaveAdd, nil
+}
+
+// readStatList reads an item stat list: 9-bit stat IDs, each followed by its
+// parameter and value as wide as ItemStatCost says, ending with 0x1FF
+func readStatList(r *bitReader, data *gamedata.Data) (stat.Stats, error) {
+	var list stat.Stats
+	for {
+		id := int(r.bits(9))
+		if id == 0x1FF {
+			return list, r.err()
+		}
+
+		count := statValueCounts[id]
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			sc, ok := data.Stat(id + i)
+			if !ok || sc.SaveBits == 0 {
+				// Without the width the rest of the list can't be read
+				return nil, fmt.Errorf("stat %d has no save bits in ItemStatCost; extract the game's ItemStatCost.txt to read it", id+i)
+			}
+
+			layer := 0
+			if sc.SaveParamBits > 0 {
+				layer = int(r.bits(sc.SaveParamBits))
+			}
+			value := int(r.bits(sc.SaveBits)) - sc.SaveAdd
+			list = append(list, stat.Data{ID: stat.ID(id + i), Value: value, Layer: layer})
+		}
+
+		if err := r.err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// skipSaveName reads past a NUL-terminated ear or personalization name.
+// D2R widened the characters from 7 to 8 bits after its first save version,
+// going by community notes rather than a real save.
+func skipSaveName(r *bitReader, version int) {
+	width := 7
+	if version > minSaveVersion {
+		width = 8
+	}
+	for i := 0; i < 16; i++ {
+		if r.bits(width) == 0 {
+			return
+		}
+	}
+}
+
+// err reports a read past the end of the data
+func (r *bitReader) err() error {
+	if r.overrun {
+		return errTruncated
+	}
+	return nil
+}
+
+// saveModels converts save items to the model the memory reader produces.
+// Ears are left out, and so are items whose base isn't in the item tables;
+// their codes are returned.
+func saveModels(data *gamedata.Data, items []*saveItem, stashTab int) ([]*models.Item, []string) {
+	var out []*models.Item
+	var unknown []string
+	for _, it := range items {
+		if parsed := it.model(data, stashTab); parsed != nil {
+			out = append(out, parsed)
+		} else if it.code != "ear" {
+			unknown = append(unknown, it.code)
+		}
+	}
+	return out, unknown
+}
+
+// model builds the item the memory reader would produce for the same item
+func (it *saveItem) model(data *gamedata.Data, stashTab int) *models.Item {
+	base, ok := data.Base(it.code)
+	if !ok {
+		return nil
+	}
+
+	quality := saveQualityNames[it.quality]
+	if quality == "" {
+		quality = "Normal"
+	}
+
+	// Socketed runes and gems add fixed stats; jewels carry their own
+	slot := baseSocketSlot(base)
+	var socketed []models.SocketedItem
+	var filler stat.Stats
+	var runes []string
+	for _, child := range it.socketedIn {
+		childBase, _ := data.Base(child.code)
+		name := childBase.Name
+		if name == "" {
+			name = child.code
+		}
+
+		added := child.stats
+		socketType := socketItemType(data, child.code)
+		if socketType == "rune" {
+			runes = append(runes, child.code)
+		}
+		if gem, ok := data.Gem(child.code); ok {
+			added = gemStats(data, gem, slot)
+		}
+		socketed = append(socketed, models.SocketedItem{
+			Name:       name,
+			Type:       socketType,
+			Properties: statProperties(added),
+			Stats:      rawStats(added),
+		})
+		filler = append(filler, added...)
+	}
+
+	runeword := ""
+	if it.runeword {
+		// The runes table's keys don't reliably match the ID saves store,
+		// so the runeword is named from the runes in its sockets
+		if len(runes) > 0 && len(runes) == len(it.socketedIn) {
+			runeword = recipeRuneword(data, runes, quality, base.Type)
+		}
+	}
+
+	own := append(append(stat.Stats{}, it.stats...), it.rwStats...)
+	total := append(append(stat.Stats{}, own...), filler...)
+	affixes := newAffixes(quality, it.prefixes, it.suffixes, it.rareNames[0], it.rareNames[1])
+
+	name := base.Name
+	switch {
+	case runeword != "":
+		name = runeword
+	case it.quality == saveQualityUnique:
+		if u, ok := data.UniqueByID(it.uniqueID); ok {
+			name = u.Name
+		}
+	case it.quality == saveQualitySet:
+		if s, ok := data.SetItemByID(it.setID); ok {
+			name = s.Name
+		}
+	case rareName(affixes) != "":
+		name = rareName(affixes)
+	case it.quality == saveQualityMagic && affixes != nil:
+		name = magicName(base.Name, affixes)
+	}
+
+	properties := statProperties(own)
+	if len(it.socketedIn) > 0 || it.sockets > 0 {
+		properties = append(properties, models.Property{Name: "Sockets", Value: it.sockets})
+	}
+	if it.ethereal {
+		properties = append(properties, models.Property{Name: "Ethereal", Value: true})
+	}
+
+	item := &models.Item{
+		Name:          name,
+		BaseName:      base.Name,
+		Code:          it.code,
+		UnitID:        int(it.id),
+		Type:          base.Type,
+		Quality:       quality,
+		Properties:    properties,
+		Stats:         rawStats(total),
+		Requirements:  it.requirements(data, base, total),
+		Sockets:       it.sockets,
+		Defense:       it.totalDefense(total),
+		Damage:        it.damage(base, total),
+		IsIdentified:  it.identified,
+		IsEthereal:    it.ethereal,
+		Affixes:       affixes,
+		SocketedItems: socketed,
+		Runeword:      runeword,
+		Location:      it.locationModel(stashTab),
+	}
+	// Simple items like runes and gems don't store their level
+	if !it.simple {
+		item.ItemLevel = &it.level
+	}
+	return item
+}
+
+// totalDefense applies enhanced and flat defense to the saved base defense
+func (it *saveItem) totalDefense(itemStats stat.Stats) int {
+	if it.defense == 0 {
+		return 0
+	}
+	defense := it.defense
+	if ed, ok := itemStats.FindStat(stat.ID(16), 0); ok {
+		defense = defense * (100 + ed.Value) / 100
+	}
+	if flat, ok := itemStats.FindStat(stat.Defense, 0); ok {
+		defense += flat.Value
+	}
+	return defense
+}
+
+// damage returns the weapon's physical damage with enhanced and flat damage
+// applied. Two-handed damage wins for weapons that have both, like the memory reader.
+func (it *saveItem) damage(base gamedata.ItemBase, itemStats stat.Stats) *models.DamageRange {
+	minDamage, maxDamage := base.MinDamage, base.MaxDamage
+	minStat, maxStat := 21, 22
+	if base.TwoHandMaxDamage > 0 {
+		minDamage, maxDamage = base.TwoHandMinDamage, base.TwoHandMaxDamage
+		minStat, maxStat = 23, 24
+	}
+	if maxDamage == 0 {
+		return nil
+	}
+
+	if it.ethereal {
+		minDamage, maxDamage = minDamage*3/2, maxDamage*3/2
+	}
+	if ed, ok := itemStats.FindStat(stat.ID(17), 0); ok {
+		minDamage = minDamage * (100 + ed.Value) / 100
+		maxDamage = maxDamage * (100 + ed.Value) / 100
+	}
+	if flat, ok := itemStats.FindStat(stat.ID(minStat), 0); ok {
+		minDamage += flat.Value
+	}
+	if flat, ok := itemStats.FindStat(stat.ID(maxStat), 0); ok {
+		maxDamage += flat.Value
+	}
+
+	return &models.DamageRange{Min: minDamage, Max: maxDamage, Type: "Physical"}
+}
+
+// requirements works out the level, strength and dexterity needed to equip
+// the item from its base, unique or set row and affixes
+func (it *saveItem) requirements(data *gamedata.Data, base gamedata.ItemBase, itemStats stat.Stats) *models.Requirements {
+	reqs := &models.Requirements{
+		Level:     base.LevelReq,
+		Strength:  base.ReqStr,
+		Dexterity: base.ReqDex,
+	}
+
+	if u, ok := data.UniqueByID(it.uniqueID); ok && it.quality == saveQualityUnique {
+		reqs.Level = max(reqs.Level, u.LevelReq)
+	}
+	if s, ok := data.SetItemByID(it.setID); ok && it.quality == saveQualitySet {
+		reqs.Level = max(reqs.Level, s.LevelReq)
+	}
+	for _, id := range it.prefixes {
+		if affix, ok := data.MagicPrefix(id); ok {
+			reqs.Level = max(reqs.Level, affix.LevelReq)
+		}
+	}
+	for _, id := range it.suffixes {
+		if affix, ok := data.MagicSuffix(id); ok {
+			reqs.Level = max(reqs.Level, affix.LevelReq)
+		}
+	}
+
+	// "Requirements -X%" scales strength and dexterity
+	if s, ok := itemStats.FindStat(stat.Requirements, 0); ok {
+		reqs.Strength = reqs.Strength * (100 + s.Value) / 100
+		reqs.Dexterity = reqs.Dexterity * (100 + s.Value) / 100
+	}
+
+	// Ethereal items need 10 less strength and dexterity
+	if it.ethereal {
+		reqs.Strength = max(reqs.Strength-10, 0)
+		reqs.Dexterity = max(reqs.Dexterity-10, 0)
+	}
+
+	if reqs.Level == 0 && reqs.Strength == 0 && reqs.Dexterity == 0 {
+		return nil
+	}
+	return reqs
+}
+
+// locationModel converts the saved location to the model. Shared stash
+// items are tagged with their tab; stashTab is 0 for character saves.
+// Stored items keep their column and row.
+func (it *saveItem) locationModel(stashTab int) *models.ItemLocation {
+	if stashTab > 0 {
+		return &models.ItemLocation{Type: string(item.LocationSharedStash), Tab: stashTab, X: it.column, Y: it.row}
+	}
+
+	switch it.location {
+	case saveLocationEquipped:
+		return &models.ItemLocation{Type: string(item.LocationEquipped), Slot: saveBodySlots[it.equipped]}
+	case saveLocationStored:
+		switch it.storage {
+		case saveStorageInventory:
+			return &models.ItemLocation{Type: string(item.LocationInventory), X: it.column, Y: it.row}
+		case saveStorageCube:
+			return &models.ItemLocation{Type: string(item.LocationCube), X: it.column, Y: it.row}
+		case saveStorageStash:
+			return &models.ItemLocation{Type: string(item.LocationStash), X: it.column, Y: it.row}
+		}
+	case saveLocationBelt:
+		return &models.ItemLocation{Type: string(item.LocationBelt), X: it.column}
+	case saveLocationCursor:
+		return &models.ItemLocation{Type: string(item.LocationCursor)}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/hectorgimenez/d2go/pkg/data/skill"
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// classNames lists the character classes in the order the game numbers them
+var classNames = []string{"Amazon", "Sorceress", "Necromancer", "Paladin", "Barbarian", "Druid", "Assassin"}
+
+// skillTabNames holds the three skill tabs of each class, indexed like classNames
+var skillTabNames = [][3]string{
+	{"Bow and Crossbow Skills", "Passive and Magic Skills", "Javelin and Spear Skills"},
+	{"Fire Skills", "Lightning Skills", "Cold Skills"},
+	{"Curses", "Poison and Bone Skills", "Summoning Skills"},
+	{"Combat Skills", "Offensive Auras", "Defensive Auras"},
+	{"Combat Skills", "Masteries", "Warcries"},
+	{"Summoning Skills", "Shape Shifting Skills", "Elemental Skills"},
+	{"Traps", "Shadow Disciplines", "Martial Arts"},
+}
+
+// elementNames names the elements used by the "+X to Fire Skills" stat
+var elementNames = map[int]string{
+	1: "Fire",
+	2: "Lightning",
+	3: "Magic",
+	4: "Cold",
+	5: "Poison",
+}
+
+// skillClassRanges holds the first and last skill ID of each class, indexed like classNames
+var skillClassRanges = [][2]int{
+	{6, 35},
+	{36, 65},
+	{66, 95},
+	{96, 125},
+	{126, 155},
+	{221, 250},
+	{251, 280},
+}
+
+// skillName returns the in-game name of a skill
+func skillName(id int) string {
+	if name, ok := skill.SkillNames[skill.ID(id)]; ok && name != "" {
+		return name
+	}
+	return fmt.Sprintf("Skill %d", id)
+}
+
+// skillClass returns the class that owns a skill, or -1 for non-class skills
+func skillClass(id int) int {
+	for class, r := range skillClassRanges {
+		if id >= r[0] && id <= r[1] {
+			return class
+		}
+	}
+	return -1
+}
+
+// classSkillName names a +X to class skills bonus, e.g. "+2 to Paladin Skill Levels"
+func classSkillName(value, class int) string {
+	if class < 0 || class >= len(classNames) {
+		return ""
+	}
+	return fmt.Sprintf("+%d to %s Skill Levels", value, classNames[class])
+}
+
+// singleSkillName names a +X to a class skill, e.g. "+3 to Teleport (Sorceress Only)".
+// The second result is the matching Traderie property.
+func singleSkillName(value, skillID int) (string, string) {
+	name := skillName(skillID)
+	class := skillClass(skillID)
+	if class < 0 {
+		return fmt.Sprintf("+%d to %s", value, name), ""
+	}
+
+	only := fmt.Sprintf("%s (%s Only)", name, classNames[class])
+	return fmt.Sprintf("+%d to %s", value, only), "to " + only
+}
+
+// oskillName names a +X to any skill usable by every class, e.g. "+3 to Teleport"
+func oskillName(value, skillID int) (string, string) {
+	name := skillName(skillID)
+	return fmt.Sprintf("+%d to %s", value, name), fmt.Sprintf("to %s (Any Class)", name)
+}
+
+// skillTabName names a +X to skill tab bonus, e.g. "+3 to Warcries (Barbarian Only)".
+// The layer packs the class and tab as class*8 + tab.
+func skillTabName(value, layer int) (string, string) {
+	class, tab := layer/8, layer%8
+	if class >= len(skillTabNames) || tab >= len(skillTabNames[class]) {
+		return "", ""
+	}
+
+	only := fmt.Sprintf("%s (%s Only)", skillTabNames[class][tab], classNames[class])
+	return fmt.Sprintf("+%d to %s", value, only), "to " + only
+}
+
+// elementalSkillName names a +X to elemental skills bonus, e.g. "+1 to Fire Skills"
+func elementalSkillName(value, element int) (string, string) {
+	name, ok := elementNames[element]
+	if !ok {
+		return "", ""
+	}
+	return fmt.Sprintf("+%d to %s Skills", value, name), fmt.Sprintf("to %s Skills", name)
+}
+
+// auraName names an aura granted while the item is equipped, e.g. "Level 12 Fanaticism Aura When Equipped"
+func auraName(level, skillID int) string {
+	return fmt.Sprintf("Level %d %s Aura When Equipped", level, skillName(skillID))
+}
+
+// procTrigger describes when a chance-to-cast stat fires
+type procTrigger struct {
+	trigger string // short trigger name stored on the property
+	phrase  string // tooltip wording
+}
+
+// procTriggers maps chance-to-cast stat keys to their trigger
+var procTriggers = map[string]procTrigger{
+	"item_skillonattack":  {"attack", "on attack"},
+	"item_skillonkill":    {"kill", "when you Kill an Enemy"},
+	"item_skillondeath":   {"death", "when you Die"},
+	"item_skillonhit":     {"striking", "on striking"},
+	"item_skillonlevelup": {"level-up", "when you Level-Up"},
+	"item_skillongethit":  {"struck", "when struck"},
+}
+
+// skillProcProperty decodes a chance-to-cast or charged skill stat. Both pack
+// the skill and its level into the layer as skill<<6 | level; chance-to-cast
+// stores the chance as the value, charges store max<<8 | current.
+func skillProcProperty(entry stats.Entry, value, layer int) models.Property {
+	skillID, level := layer>>6, layer&0x3F
+	proc := &models.SkillProc{
+		SkillID: skillID,
+		Skill:   skillName(skillID),
+		Level:   level,
+	}
+
+	if entry.Encoding == stats.EncodingCharges {
+		proc.Charges = value & 0xFF
+		proc.MaxCharges = value >> 8
+		return models.Property{
+			Name:  fmt.Sprintf("Level %d %s (%d/%d Charges)", level, proc.Skill, proc.Charges, proc.MaxCharges),
+			Value: level,
+			Skill: proc,
+		}
+	}
+
+	proc.Chance = value
+	name := entry.Name
+	if t, ok := procTriggers[entry.Key]; ok {
+		proc.Trigger = t.trigger
+		name = fmt.Sprintf("%d%% Chance to cast level %d %s %s", value, level, proc.Skill, t.phrase)
+	}
+	return models.Property{
+		Name:  name,
+		Value: value,
+		Skill: proc,
+	}
+}
+
+// DescribeSkillStat returns the tooltip line of a stat whose layer holds a
+// skill, class, skill tab or element, or "" for any other stat
+func DescribeSkillStat(statID, value, layer int) string {
+	entry, ok := stats.Default().Lookup(statID)
+	if !ok {
+		return ""
+	}
+
+	switch entry.Encoding {
+	case stats.EncodingSkillChance, stats.EncodingCharges:
+		return skillProcProperty(entry, value, layer).Name
+	case stats.EncodingClassSkills, stats.EncodingSkill, stats.EncodingSkillTab, stats.EncodingElementalSkills:
+		name, _ := mapStatToTraderie(int16(statID), value, layer)
+		return name
+	}
+	return ""
+}
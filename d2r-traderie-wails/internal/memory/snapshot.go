@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// SnapshotSource replays recorded d2go snapshots instead of reading live memory.
+// Each call to GetHoveredItem advances to the next snapshot and wraps around at the end.
+type SnapshotSource struct {
+	snapshots []snapshot
+	next      int
+	mu        sync.Mutex
+}
+
+// snapshot is a single recorded game state loaded from disk
+type snapshot struct {
+	path     string
+	gameData data.Data
+}
+
+// NewSnapshotSource loads snapshots from a JSON file or a directory of JSON files
+func NewSnapshotSource(path string) (*SnapshotSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot path: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		// Replay in a stable order
+		sort.Strings(files)
+	}
+
+	src := &SnapshotSource{}
+	for _, file := range files {
+		gameData, err := LoadSnapshot(file)
+		if err != nil {
+			log.Printf("⚠️ Skipping snapshot %s: %v", filepath.Base(file), err)
+			continue
+		}
+		src.snapshots = append(src.snapshots, snapshot{path: file, gameData: gameData})
+	}
+
+	if len(src.snapshots) == 0 {
+		return nil, fmt.Errorf("no usable snapshots found in %s", path)
+	}
+
+	log.Printf("✓ Loaded %d item snapshots from %s", len(src.snapshots), path)
+	return src, nil
+}
+
+// LoadSnapshot reads a snapshot file holding a full d2go GameData, a single
+// d2go Item, or a recorded Capture. Lone items are treated as hovered where they were recorded.
+func LoadSnapshot(path string) (data.Data, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return data.Data{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	// Peek at the top-level keys to tell the formats apart
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return data.Data{}, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	if _, ok := probe["Inventory"]; ok {
+		var gameData data.Data
+		if err := json.Unmarshal(raw, &gameData); err != nil {
+			return data.Data{}, fmt.Errorf("failed to parse game data snapshot: %w", err)
+		}
+		return gameData, nil
+	}
+
+	if _, ok := probe["version"]; ok {
+		capture, err := readCapture(raw)
+		if err != nil {
+			return data.Data{}, err
+		}
+		return hoveredSnapshot(capture.Item), nil
+	}
+
+	var d2item data.Item
+	if err := json.Unmarshal(raw, &d2item); err != nil {
+		return data.Data{}, fmt.Errorf("failed to parse item snapshot: %w", err)
+	}
+	if d2item.Name == "" {
+		return data.Data{}, fmt.Errorf("snapshot contains neither game data nor an item")
+	}
+
+	return hoveredSnapshot(d2item), nil
+}
+
+// hoveredSnapshot wraps a single item in game data as if it were hovered,
+// keeping the location it was recorded in
+func hoveredSnapshot(d2item data.Item) data.Data {
+	d2item.IsHovered = true
+
+	var gameData data.Data
+	gameData.Inventory.AllItems = []data.Item{d2item}
+	return gameData
+}
+
+// GetHoveredItem replays the next recorded snapshot
+func (s *SnapshotSource) GetHoveredItem() (*models.Item, error) {
+	s.mu.Lock()
+	snap := s.snapshots[s.next]
+	s.next = (s.next + 1) % len(s.snapshots)
+	s.mu.Unlock()
+
+	log.Printf("Replaying snapshot %s", strings.TrimSuffix(filepath.Base(snap.path), ".json"))
+
+	d2item, err := findHoveredItem(snap.gameData)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := parseItem(d2item)
+	parsed.Character = readCharacter(snap.gameData, nil)
+	return parsed, nil
+}
+
+// ScanAllItems scans every item in the snapshot that GetHoveredItem would replay next
+func (s *SnapshotSource) ScanAllItems() ([]*models.Item, error) {
+	s.mu.Lock()
+	snap := s.snapshots[s.next]
+	s.mu.Unlock()
+
+	items := collectItems(snap.gameData)
+	tagCharacter(items, readCharacter(snap.gameData, nil))
+	return items, nil
+}
+
+// Close is a no-op for snapshot replay
+func (s *SnapshotSource) Close() error {
+	return nil
+}
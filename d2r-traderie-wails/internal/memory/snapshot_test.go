@@ -0,0 +1,234 @@
+package memory
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+)
+
+// recordScan writes a capture of the item the way record mode does and returns it
+func recordScan(t *testing.T, dir string, d2item data.Item) *Capture {
+	t.Helper()
+	path, err := WriteCapture(dir, &d2item, parseItem(&d2item))
+	if err != nil {
+		t.Fatalf("WriteCapture: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	capture, err := readCapture(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return capture
+}
+
+// writeJSON writes v as a snapshot file
+func writeJSON(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sameJSON reports whether two values serialize identically
+func sameJSON(t *testing.T, got, want interface{}) bool {
+	t.Helper()
+	g, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(g) == string(w)
+}
+
+func TestReplayMatchesCapture(t *testing.T) {
+	tests := []struct {
+		name   string
+		d2item data.Item
+	}{
+		{
+			name: "shared stash",
+			d2item: data.Item{UnitID: 1, Name: "CrystalSword", Quality: item.QualityUnique, Identified: true,
+				Location: item.Location{LocationType: item.LocationSharedStash, Page: 2}},
+		},
+		{
+			name: "equipped on the mercenary",
+			d2item: data.Item{UnitID: 2, Name: "CrystalSword", Quality: item.QualitySuperior, Identified: true,
+				Location: item.Location{LocationType: item.LocationMercenary, BodyLocation: "right_arm"}},
+		},
+		{
+			name: "held on the cursor",
+			d2item: data.Item{UnitID: 3, Name: "CrystalSword", Quality: item.QualityNormal,
+				Location: item.Location{LocationType: item.LocationCursor}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			capture := recordScan(t, dir, tt.d2item)
+
+			source, err := NewSnapshotSource(dir)
+			if err != nil {
+				t.Fatalf("NewSnapshotSource: %v", err)
+			}
+			replayed, err := source.GetHoveredItem()
+			if err != nil {
+				t.Fatalf("GetHoveredItem: %v", err)
+			}
+
+			if replayed.Location.Type != string(tt.d2item.Location.LocationType) {
+				t.Errorf("replayed location = %q, want the captured %q", replayed.Location.Type, tt.d2item.Location.LocationType)
+			}
+			if !sameJSON(t, replayed, capture.Parsed) {
+				got, _ := json.Marshal(replayed)
+				want, _ := json.Marshal(capture.Parsed)
+				t.Errorf("replay differs from the capture\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestRecordFullScan(t *testing.T) {
+	dir := t.TempDir()
+	gameData := data.Data{}
+	gameData.Inventory.AllItems = []data.Item{
+		{UnitID: 1, Name: "CrystalSword", Location: item.Location{LocationType: item.LocationInventory}},
+		{UnitID: 2, Name: "CrystalSword", Location: item.Location{LocationType: item.LocationGround}},
+		{UnitID: 3, Name: "CrystalSword", Location: item.Location{LocationType: item.LocationStash}},
+	}
+
+	d2items := scannedItems(gameData)
+	items := parseItems(d2items)
+	recordItems(dir, d2items, items)
+
+	source, err := NewSnapshotSource(dir)
+	if err != nil {
+		t.Fatalf("NewSnapshotSource: %v", err)
+	}
+	// Items on the ground aren't scanned, so only the other two are recorded
+	replayed := map[string]bool{}
+	for i := 0; i < len(items); i++ {
+		item, err := source.GetHoveredItem()
+		if err != nil {
+			t.Fatal(err)
+		}
+		replayed[item.Location.Type] = true
+	}
+	if want := map[string]bool{"stash": true, "inventory": true}; !reflect.DeepEqual(replayed, want) {
+		t.Errorf("replayed %v, want the scanned %v", replayed, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != len(items) {
+		t.Errorf("recorded %d captures, want one per scanned item (%d)", len(entries), len(items))
+	}
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	stashed := data.Item{UnitID: 7, Name: "CrystalSword", Quality: item.QualityNormal,
+		Location: item.Location{LocationType: item.LocationStash}}
+
+	gameData := data.Data{PlayerUnit: data.PlayerUnit{Name: "Tradebot"}}
+	gameData.Inventory.AllItems = []data.Item{
+		{UnitID: 6, Name: "CrystalSword", Location: item.Location{LocationType: item.LocationInventory}},
+		stashed,
+	}
+	gameData.HoverData = data.HoverData{IsHovered: true, UnitID: 7, UnitType: itemUnitType}
+	writeJSON(t, filepath.Join(dir, "game.json"), gameData)
+	writeJSON(t, filepath.Join(dir, "item.json"), stashed)
+	writeJSON(t, filepath.Join(dir, "neither.json"), map[string]int{"unrelated": 1})
+	writeJSON(t, filepath.Join(dir, "future.json"), Capture{Version: CaptureVersion + 1, Item: stashed})
+
+	tests := []struct {
+		file      string
+		location  string
+		character string
+		wantErr   bool
+	}{
+		{file: "game.json", location: "stash", character: "Tradebot"},
+		{file: "item.json", location: "stash"},
+		{file: "neither.json", wantErr: true},
+		{file: "future.json", wantErr: true},
+		{file: "missing.json", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			gameData, err := LoadSnapshot(filepath.Join(dir, tt.file))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("LoadSnapshot() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadSnapshot: %v", err)
+			}
+
+			d2item, err := findHoveredItem(gameData)
+			if err != nil {
+				t.Fatalf("findHoveredItem: %v", err)
+			}
+			if d2item.UnitID != 7 || string(d2item.Location.LocationType) != tt.location {
+				t.Errorf("hovered item = unit %d in %s, want unit 7 in %s", d2item.UnitID, d2item.Location.LocationType, tt.location)
+			}
+			if got := gameData.PlayerUnit.Name; got != tt.character {
+				t.Errorf("character = %q, want %q", got, tt.character)
+			}
+		})
+	}
+}
+
+func TestSnapshotSourceReplaysInOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, filepath.Join(dir, "1.json"), data.Item{UnitID: 1, Name: "CrystalSword", Location: item.Location{LocationType: item.LocationCube}})
+	writeJSON(t, filepath.Join(dir, "2.json"), data.Item{UnitID: 2, Name: "CrystalSword", Location: item.Location{LocationType: item.LocationInventory}})
+	writeJSON(t, filepath.Join(dir, "3.json"), map[string]int{"unrelated": 1})
+
+	source, err := NewSnapshotSource(dir)
+	if err != nil {
+		t.Fatalf("NewSnapshotSource: %v", err)
+	}
+
+	// The unusable snapshot is skipped and replay wraps around
+	for _, want := range []string{"cube", "inventory", "cube"} {
+		items, err := source.ScanAllItems()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 || items[0].Location.Type != want {
+			t.Fatalf("ScanAllItems() before the next replay = %+v, want the %s item", items, want)
+		}
+
+		item, err := source.GetHoveredItem()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item.Location.Type != want {
+			t.Errorf("replayed location = %q, want %q", item.Location.Type, want)
+		}
+	}
+}
+
+func TestNewSnapshotSourceWithoutSnapshots(t *testing.T) {
+	if _, err := NewSnapshotSource(t.TempDir()); err == nil {
+		t.Error("NewSnapshotSource() of an empty directory succeeded")
+	}
+	if _, err := NewSnapshotSource(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("NewSnapshotSource() of a missing path succeeded")
+	}
+}
@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// socketContents describes what is socketed in an item
+type socketContents struct {
+	items    []models.SocketedItem
+	runeword string
+	filler   stat.Stats // Stats the socketed items add to their parent
+}
+
+// parseSockets reads the runes, gems and jewels socketed in an item, names
+// the runeword the game flags it as and collects the stats the filler contributes
+func parseSockets(d2item *data.Item) socketContents {
+	var contents socketContents
+	var runes []string
+	data := gamedata.Default()
+	slot := socketSlot(d2item)
+
+	for i := range d2item.Sockets {
+		socket := &d2item.Sockets[i]
+		name := string(socket.Name)
+		code := socket.Desc().Code
+
+		// Jewels carry their own stats; runes and gems add fixed ones from gems.txt
+		added := socket.Stats
+		socketType := socketItemType(data, code)
+		if socketType == "rune" {
+			runes = append(runes, code)
+		}
+		if gem, ok := data.Gem(code); ok && len(added) == 0 {
+			added = gemStats(data, gem, slot)
+		}
+
+		contents.items = append(contents.items, models.SocketedItem{
+			Name:       name,
+			Type:       socketType,
+			Properties: statProperties(added),
+			Stats:      rawStats(added),
+		})
+		contents.filler = append(contents.filler, added...)
+	}
+
+	// Only items the game flags as runewords are named after one. The rune
+	// order is the fallback when d2go doesn't know the runeword's name, and
+	// needs every socket filled with runes in recipe order.
+	if d2item.IsRuneword {
+		contents.runeword = string(d2item.RunewordName)
+		if contents.runeword == "" && len(runes) > 0 && len(runes) == len(d2item.Sockets) {
+			contents.runeword = recipeRuneword(data, runes, d2item.Quality.ToString(), getItemType(d2item))
+		}
+		if contents.runeword != "" {
+			log.Printf("✓ Detected runeword: %s (%s)", contents.runeword, strings.Join(runes, " "))
+		}
+	}
+
+	return contents
+}
+
+// gemStats returns the fixed stats a gem or rune adds to a weapon, armor or shield
+func gemStats(data *gamedata.Data, gem gamedata.Gem, slot string) stat.Stats {
+	mods := gem.Helm
+	switch slot {
+	case "weapon":
+		mods = gem.Weapon
+	case "shield":
+		mods = gem.Shield
+	}
+
+	var stats stat.Stats
+	for _, mod := range mods {
+		for _, s := range data.FixedStats(mod) {
+			stats = append(stats, stat.Data{ID: stat.ID(s.ID), Layer: s.Layer, Value: s.Value})
+		}
+	}
+	return stats
+}
+
+// socketSlot tells whether socket bonuses use the weapon, armor or shield column
+func socketSlot(d2item *data.Item) string {
+	desc := d2item.Desc()
+	if desc.MaxDamage > 0 || desc.TwoHandMaxDamage > 0 {
+		return "weapon"
+	}
+
+	switch d2item.Type().Code {
+	case "shie", "ashd", "head":
+		return "shield"
+	}
+	return "armor"
+}
+
+// socketItemType classifies a socketed item as a rune, jewel or gem by its base's item type
+func socketItemType(data *gamedata.Data, code string) string {
+	base, _ := data.Base(code)
+	switch {
+	case base.Type == "rune":
+		return "rune"
+	case base.Type == "jewl":
+		return "jewel"
+	case base.Type != "" && data.MatchesTypes(base.Type, []string{"gem"}, nil):
+		return "gem"
+	}
+	return "other"
+}
+
+// subtractStats removes the filler's contribution from an item's stats so
+// only the item's own (or the runeword's own) stats remain
+func subtractStats(itemStats, filler stat.Stats) stat.Stats {
+	if len(filler) == 0 {
+		return itemStats
+	}
+
+	type key struct {
+		id    stat.ID
+		layer int
+	}
+	added := make(map[key]int)
+	for _, s := range filler {
+		added[key{s.ID, s.Layer}] += s.Value
+	}
+
+	own := make(stat.Stats, 0, len(itemStats))
+	for _, s := range itemStats {
+		s.Value -= added[key{s.ID, s.Layer}]
+		if s.Value != 0 {
+			own = append(own, s)
+		}
+	}
+	return own
+}
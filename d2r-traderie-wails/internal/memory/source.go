@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/hectorgimenez/d2go/pkg/data/item"
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// ItemSource is anything that can hand the app a scanned item. The live
+// memory Reader is the production implementation; SnapshotSource replays
+// recorded game data so the scan/map/post pipeline can run without D2R.
+type ItemSource interface {
+	// GetHoveredItem returns the item currently held on the cursor or hovered
+	GetHoveredItem() (*models.Item, error)
+
+	// ScanAllItems returns every stashed, carried, cubed and equipped item
+	ScanAllItems() ([]*models.Item, error)
+
+	// Close releases any resources held by the source
+	Close() error
+}
+
+// itemUnitType is the unit type D2R reports in hover data for items
+const itemUnitType = 4
+
+// findHoveredItem picks the cursor-held or hovered item out of a game data snapshot.
+// Hovered items are matched in every location d2go exposes: ground, inventory,
+// stash, shared stash tabs, cube, belt, equipment, mercenary and sockets.
+func findHoveredItem(gameData data.Data) (*data.Item, error) {
+	// 1. An item held on the cursor always wins
+	for i := range gameData.Inventory.AllItems {
+		d2item := &gameData.Inventory.AllItems[i]
+		if d2item.Location.LocationType == item.LocationCursor {
+			log.Printf("✓ Found item on cursor: %s", d2item.Name)
+			return d2item, nil
+		}
+	}
+
+	// 2. Match the hovered unit against every item. Unit IDs are only unique per
+	// unit type, so without the type check a hovered monster could match an item.
+	hover := gameData.HoverData
+	if hover.IsHovered && hover.UnitType == itemUnitType {
+		if d2item := findItemByUnitID(gameData.Inventory.AllItems, hover.UnitID); d2item != nil {
+			log.Printf("✓ Found hovered item in %s: %s", d2item.Location.LocationType, d2item.Name)
+			return d2item, nil
+		}
+	}
+
+	// 3. Fall back to the item d2go flagged as hovered, which covers panels
+	// (stash, cube, mercenary) where the hover unit isn't always refreshed
+	for i := range gameData.Inventory.AllItems {
+		d2item := &gameData.Inventory.AllItems[i]
+		if d2item.IsHovered {
+			log.Printf("✓ Found flagged hovered item in %s: %s", d2item.Location.LocationType, d2item.Name)
+			return d2item, nil
+		}
+	}
+
+	// No item found
+	return nil, fmt.Errorf("no item found - hover over an item (ground, inventory, stash, cube or equipped) or hold it on the cursor and press F9")
+}
+
+// findItemByUnitID searches items and their socketed children for a unit ID
+func findItemByUnitID(items []data.Item, unitID data.UnitID) *data.Item {
+	for i := range items {
+		if items[i].UnitID == unitID {
+			return &items[i]
+		}
+		if socketed := findItemByUnitID(items[i].Sockets, unitID); socketed != nil {
+			return socketed
+		}
+	}
+	return nil
+}
+
+// scannedLocations are the item locations included in a full scan, in display order
+var scannedLocations = []item.LocationType{
+	item.LocationStash,
+	item.LocationSharedStash,
+	item.LocationInventory,
+	item.LocationCube,
+	item.LocationEquipped,
+	item.LocationMercenary,
+}
+
+// collectItems parses every item in the stash, shared stash tabs, inventory,
+// Horadric Cube, equipment and mercenary equipment, tagged with its location
+func collectItems(gameData data.Data) []*models.Item {
+	return parseItems(scannedItems(gameData))
+}
+
+// scannedItems returns the d2go items a full scan includes, grouped by
+// location and then by stash tab, so the result reads like the game UI
+func scannedItems(gameData data.Data) []*data.Item {
+	order := make(map[item.LocationType]int, len(scannedLocations))
+	for i, loc := range scannedLocations {
+		order[loc] = i
+	}
+
+	var d2items []*data.Item
+	for i := range gameData.Inventory.AllItems {
+		d2item := &gameData.Inventory.AllItems[i]
+		if _, ok := order[d2item.Location.LocationType]; ok {
+			d2items = append(d2items, d2item)
+		}
+	}
+
+	sort.SliceStable(d2items, func(i, j int) bool {
+		li, lj := d2items[i].Location, d2items[j].Location
+		if li.LocationType != lj.LocationType {
+			return order[li.LocationType] < order[lj.LocationType]
+		}
+		return li.Page < lj.Page
+	})
+
+	return d2items
+}
+
+// parseItems converts d2go items to our model, in the same order
+func parseItems(d2items []*data.Item) []*models.Item {
+	items := make([]*models.Item, 0, len(d2items))
+	for _, d2item := range d2items {
+		items = append(items, parseItem(d2item))
+	}
+	return items
+}
+
+// parseLocation converts a d2go item location to our model
+func parseLocation(loc item.Location) *models.ItemLocation {
+	location := &models.ItemLocation{
+		Type: string(loc.LocationType),
+	}
+
+	switch loc.LocationType {
+	case item.LocationSharedStash:
+		location.Tab = loc.Page
+	case item.LocationEquipped, item.LocationMercenary:
+		location.Slot = string(loc.BodyLocation)
+	}
+
+	return location
+}
+
+// realmFlags are a character's hardcore, ladder and expansion flags
+type realmFlags struct {
+	hardcore, ladder, expansion bool
+}
+
+// readCharacter describes the character logged into the game, or returns nil
+// at the menus. readFlags looks up the realm flags the game process keeps in
+// its character list; when it is nil or fails they're left unknown.
+func readCharacter(gameData data.Data, readFlags func(name string) (realmFlags, error)) *models.Character {
+	player := gameData.PlayerUnit
+	if player.Name == "" {
+		return nil
+	}
+
+	character := &models.Character{Name: player.Name}
+	if class := int(player.Class); class >= 0 && class < len(classNames) {
+		character.Class = classNames[class]
+	}
+	if level, ok := player.FindStat(stat.Level, 0); ok {
+		character.Level = level.Value
+	}
+
+	if readFlags != nil {
+		flags, err := readFlags(player.Name)
+		if err != nil {
+			log.Printf("⚠️ Failed to read the realm flags of %s: %v", player.Name, err)
+			return character
+		}
+		character.Hardcore = flags.hardcore
+		character.Ladder = flags.ladder
+		character.Expansion = flags.expansion
+		character.FlagsKnown = true
+	}
+	return character
+}
+
+// inOnlineGame reports whether the game is an online one. Single-player
+// games have no name, so the last game name d2go reads stays empty.
+func inOnlineGame(gameData data.Data) bool {
+	return gameData.Game.LastGameName != ""
+}
+
+// tagCharacter records the character holding each item
+func tagCharacter(items []*models.Item, character *models.Character) {
+	for _, item := range items {
+		item.Character = character
+	}
+}
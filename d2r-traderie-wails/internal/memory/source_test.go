@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+func TestReadCharacter(t *testing.T) {
+	gameData := data.Data{PlayerUnit: data.PlayerUnit{Name: "Tradebot", Class: data.Sorceress}}
+
+	tests := []struct {
+		name      string
+		readFlags func(string) (realmFlags, error)
+		want      models.Character
+	}{
+		{
+			name: "flags from the game",
+			readFlags: func(name string) (realmFlags, error) {
+				if name != "Tradebot" {
+					return realmFlags{}, errors.New("character not found")
+				}
+				return realmFlags{hardcore: true, ladder: true, expansion: true}, nil
+			},
+			want: models.Character{Name: "Tradebot", Class: "Sorceress", Hardcore: true, Ladder: true, Expansion: true, FlagsKnown: true},
+		},
+		{
+			name: "unreadable flags",
+			readFlags: func(string) (realmFlags, error) {
+				return realmFlags{}, errors.New("character data pointer is invalid")
+			},
+			want: models.Character{Name: "Tradebot", Class: "Sorceress"},
+		},
+		{
+			name: "no flag reader",
+			want: models.Character{Name: "Tradebot", Class: "Sorceress"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			character := readCharacter(gameData, tt.readFlags)
+			if character == nil || *character != tt.want {
+				t.Errorf("readCharacter() = %+v, want %+v", character, tt.want)
+			}
+		})
+	}
+
+	if character := readCharacter(data.Data{}, nil); character != nil {
+		t.Errorf("readCharacter() at the menus = %+v, want nil", character)
+	}
+}
@@ -0,0 +1,329 @@
+package memory
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// ProcessFinder locates and attaches to D2R processes. The Windows
+// implementation talks to the OS; fakes can drive the Supervisor in tests.
+type ProcessFinder interface {
+	// FindProcesses returns the PIDs of every running D2R process
+	FindProcesses() ([]uint32, error)
+
+	// Attach opens an item source reading from the given process
+	Attach(pid uint32) (ItemSource, error)
+
+	// IsAlive reports whether the process is still running
+	IsAlive(pid uint32) bool
+}
+
+// characterNamer is implemented by sources that know which character is logged in
+type characterNamer interface {
+	CharacterName() string
+}
+
+// Supervisor waits for D2R, attaches to every running instance, and
+// reattaches whenever a game exits or restarts. It is itself an ItemSource
+// that delegates to the selected instance.
+type Supervisor struct {
+	finder   ProcessFinder
+	interval time.Duration
+	onAttach func(pid uint32)
+	onDetach func(pid uint32)
+
+	games    map[uint32]ItemSource
+	names    map[uint32]string // Character logged into each game, read once per tick
+	selected uint32            // PID chosen for this session, 0 = automatic
+	mu       sync.RWMutex
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+// NewSupervisor creates a supervisor that polls for the game every interval.
+// onAttach and onDetach are called from the supervisor goroutine and may be nil.
+func NewSupervisor(finder ProcessFinder, interval time.Duration, onAttach, onDetach func(pid uint32)) *Supervisor {
+	return &Supervisor{
+		finder:   finder,
+		interval: interval,
+		onAttach: onAttach,
+		onDetach: onDetach,
+		games:    make(map[uint32]ItemSource),
+		names:    make(map[uint32]string),
+	}
+}
+
+// Start begins supervising in the background
+func (s *Supervisor) Start() {
+	s.stopChan = make(chan struct{})
+	s.doneChan = make(chan struct{})
+
+	go func() {
+		defer close(s.doneChan)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		// Try immediately rather than waiting for the first tick
+		s.Step()
+		for {
+			select {
+			case <-ticker.C:
+				s.Step()
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Step runs one iteration of the attach/detach state machine
+func (s *Supervisor) Step() {
+	// Drop games that exited
+	for _, pid := range s.attachedPIDs() {
+		if !s.finder.IsAlive(pid) {
+			log.Printf("⚠️ D2R process %d exited", pid)
+			s.detach(pid)
+		}
+	}
+
+	pids, err := s.finder.FindProcesses()
+	if err != nil {
+		return
+	}
+
+	// Attach to any game we aren't reading yet, including restarted ones
+	for _, pid := range pids {
+		s.mu.RLock()
+		_, attached := s.games[pid]
+		s.mu.RUnlock()
+		if attached {
+			continue
+		}
+
+		source, err := s.finder.Attach(pid)
+		if err != nil {
+			log.Printf("⚠️ Found D2R (PID: %d) but could not attach: %v", pid, err)
+			continue
+		}
+
+		s.mu.Lock()
+		s.games[pid] = source
+		s.mu.Unlock()
+
+		log.Printf("✅ Attached to D2R (PID: %d)", pid)
+		if s.onAttach != nil {
+			s.onAttach(pid)
+		}
+	}
+
+	s.refreshNames()
+}
+
+// refreshNames reads which character is logged into each game. Reading a
+// name costs a full pass over game memory, so it's done once per tick and
+// everything else uses the cached names.
+func (s *Supervisor) refreshNames() {
+	s.mu.RLock()
+	sources := make(map[uint32]ItemSource, len(s.games))
+	for pid, source := range s.games {
+		sources[pid] = source
+	}
+	s.mu.RUnlock()
+
+	names := make(map[uint32]string, len(sources))
+	for pid, source := range sources {
+		if namer, ok := source.(characterNamer); ok {
+			names[pid] = namer.CharacterName()
+		}
+	}
+
+	s.mu.Lock()
+	s.names = names
+	s.mu.Unlock()
+}
+
+// attachedPIDs returns the PIDs of all attached games in ascending order
+func (s *Supervisor) attachedPIDs() []uint32 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pids := make([]uint32, 0, len(s.games))
+	for pid := range s.games {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+	return pids
+}
+
+// detach drops the reader for a game and notifies listeners
+func (s *Supervisor) detach(pid uint32) {
+	s.mu.Lock()
+	source, ok := s.games[pid]
+	delete(s.games, pid)
+	delete(s.names, pid)
+	s.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	source.Close()
+
+	log.Printf("Detached from D2R (PID: %d)", pid)
+	if s.onDetach != nil {
+		s.onDetach(pid)
+	}
+}
+
+// Instances lists every attached game with the character currently logged in
+func (s *Supervisor) Instances() []models.GameInstance {
+	pids := s.attachedPIDs()
+
+	instances := make([]models.GameInstance, 0, len(pids))
+	for _, pid := range pids {
+		instances = append(instances, s.instance(pid))
+	}
+	return instances
+}
+
+// instance describes an attached game using the character name read on the last tick
+func (s *Supervisor) instance(pid uint32) models.GameInstance {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return models.GameInstance{PID: pid, Character: s.names[pid]}
+}
+
+// Select picks the game used for this session. Pass 0 to go back to automatic selection.
+func (s *Supervisor) Select(pid uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pid != 0 {
+		if _, ok := s.games[pid]; !ok {
+			return fmt.Errorf("D2R process %d is not attached", pid)
+		}
+	}
+	s.selected = pid
+	return nil
+}
+
+// Selected returns the PID picked for this session, or 0 for automatic
+func (s *Supervisor) Selected() uint32 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.selected
+}
+
+// current returns the PID of the game scans should read from
+func (s *Supervisor) current() (uint32, error) {
+	pids := s.attachedPIDs()
+	if len(pids) == 0 {
+		return 0, fmt.Errorf("not attached to D2R - waiting for the game to start (run as Administrator)")
+	}
+
+	selected := s.Selected()
+	for _, pid := range pids {
+		if pid == selected {
+			return pid, nil
+		}
+	}
+
+	if len(pids) > 1 {
+		return 0, fmt.Errorf("%d D2R instances are running - select which one to read from", len(pids))
+	}
+	return pids[0], nil
+}
+
+// forCharacter returns the PID of the game the named character is logged into
+func (s *Supervisor) forCharacter(character string) (uint32, error) {
+	for _, instance := range s.Instances() {
+		if strings.EqualFold(instance.Character, character) {
+			return instance.PID, nil
+		}
+	}
+	return 0, fmt.Errorf("no attached D2R instance has %s logged in", character)
+}
+
+// readFrom scans the hovered item of one game and records which instance it came from
+func (s *Supervisor) readFrom(pid uint32) (*models.Item, error) {
+	s.mu.RLock()
+	source, ok := s.games[pid]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("D2R process %d is not attached", pid)
+	}
+
+	item, err := source.GetHoveredItem()
+	if err != nil || item == nil {
+		return item, err
+	}
+
+	// The scan read the game anyway, so its character is fresher than the cached name
+	instance := s.instance(pid)
+	if item.Character != nil {
+		instance.Character = item.Character.Name
+	}
+	item.Instance = &instance
+	return item, nil
+}
+
+// GetHoveredItem reads the hovered item from the selected game
+func (s *Supervisor) GetHoveredItem() (*models.Item, error) {
+	pid, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return s.readFrom(pid)
+}
+
+// GetHoveredItemFor reads the hovered item from the game the named character is logged into
+func (s *Supervisor) GetHoveredItemFor(character string) (*models.Item, error) {
+	pid, err := s.forCharacter(character)
+	if err != nil {
+		return nil, err
+	}
+	return s.readFrom(pid)
+}
+
+// ScanAllItems scans every item in the selected game
+func (s *Supervisor) ScanAllItems() ([]*models.Item, error) {
+	pid, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	source := s.games[pid]
+	s.mu.RUnlock()
+
+	items, err := source.ScanAllItems()
+	if err != nil {
+		return nil, err
+	}
+
+	instance := s.instance(pid)
+	for _, item := range items {
+		item.Instance = &instance
+	}
+	return items, nil
+}
+
+// Close stops supervising and releases every attached reader
+func (s *Supervisor) Close() error {
+	if s.stopChan != nil {
+		close(s.stopChan)
+		<-s.doneChan
+		s.stopChan = nil
+	}
+	for _, pid := range s.attachedPIDs() {
+		s.detach(pid)
+	}
+	return nil
+}
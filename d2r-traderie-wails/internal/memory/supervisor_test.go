@@ -0,0 +1,278 @@
+package memory
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// fakeSource is an attached game with a fixed character and hovered item
+type fakeSource struct {
+	character string
+	item      *models.Item
+	nameReads int
+	closed    bool
+}
+
+func (f *fakeSource) GetHoveredItem() (*models.Item, error) {
+	if f.item == nil {
+		return nil, fmt.Errorf("no item found")
+	}
+	item := *f.item
+	return &item, nil
+}
+
+func (f *fakeSource) ScanAllItems() ([]*models.Item, error) {
+	item := *f.item
+	return []*models.Item{&item}, nil
+}
+
+func (f *fakeSource) CharacterName() string {
+	f.nameReads++
+	return f.character
+}
+
+func (f *fakeSource) Close() error {
+	f.closed = true
+	return nil
+}
+
+// fakeFinder stands in for the running D2R processes
+type fakeFinder struct {
+	running    map[uint32]string // PID -> character logged in
+	attachErrs map[uint32]error
+	restarted  map[uint32]bool // Games that exited and came back under the same PID
+	attached   []*fakeSource
+}
+
+func newFakeFinder() *fakeFinder {
+	return &fakeFinder{
+		running:    make(map[uint32]string),
+		attachErrs: make(map[uint32]error),
+		restarted:  make(map[uint32]bool),
+	}
+}
+
+func (f *fakeFinder) FindProcesses() ([]uint32, error) {
+	if len(f.running) == 0 {
+		return nil, fmt.Errorf("D2R window not found")
+	}
+	var pids []uint32
+	for pid := range f.running {
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+func (f *fakeFinder) Attach(pid uint32) (ItemSource, error) {
+	if err := f.attachErrs[pid]; err != nil {
+		return nil, err
+	}
+	source := &fakeSource{
+		character: f.running[pid],
+		item:      &models.Item{Name: "Shako", Character: &models.Character{Name: f.running[pid]}},
+	}
+	f.attached = append(f.attached, source)
+	return source, nil
+}
+
+func (f *fakeFinder) IsAlive(pid uint32) bool {
+	if f.restarted[pid] {
+		delete(f.restarted, pid)
+		return false
+	}
+	_, ok := f.running[pid]
+	return ok
+}
+
+// events records attach and detach callbacks in order
+type events []string
+
+func (e *events) supervisor(finder ProcessFinder) *Supervisor {
+	return NewSupervisor(finder, 0,
+		func(pid uint32) { *e = append(*e, fmt.Sprintf("attach %d", pid)) },
+		func(pid uint32) { *e = append(*e, fmt.Sprintf("detach %d", pid)) })
+}
+
+func TestSupervisorAttachDetach(t *testing.T) {
+	finder := newFakeFinder()
+	var got events
+	s := got.supervisor(finder)
+
+	// No game yet
+	s.Step()
+	if _, err := s.GetHoveredItem(); err == nil {
+		t.Error("GetHoveredItem() before the game started succeeded")
+	}
+
+	finder.running[100] = "Tradebot"
+	s.Step()
+	if want := []models.GameInstance{{PID: 100, Character: "Tradebot"}}; !reflect.DeepEqual(s.Instances(), want) {
+		t.Errorf("Instances() = %+v, want %+v", s.Instances(), want)
+	}
+
+	item, err := s.GetHoveredItem()
+	if err != nil {
+		t.Fatalf("GetHoveredItem(): %v", err)
+	}
+	if item.Instance == nil || item.Instance.PID != 100 || item.Instance.Character != "Tradebot" {
+		t.Errorf("item.Instance = %+v, want PID 100 with Tradebot", item.Instance)
+	}
+
+	delete(finder.running, 100)
+	s.Step()
+	if len(s.Instances()) != 0 {
+		t.Errorf("Instances() after the game exited = %+v, want none", s.Instances())
+	}
+	if !finder.attached[0].closed {
+		t.Error("the exited game's source wasn't closed")
+	}
+
+	if want := (events{"attach 100", "detach 100"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestSupervisorReattach(t *testing.T) {
+	tests := []struct {
+		name    string
+		restart func(f *fakeFinder)
+		want    events
+	}{
+		{
+			name: "game restarted under a new PID",
+			restart: func(f *fakeFinder) {
+				delete(f.running, 100)
+				f.running[200] = "Tradebot"
+			},
+			want: events{"attach 100", "detach 100", "attach 200"},
+		},
+		{
+			name: "game restarted under the same PID",
+			restart: func(f *fakeFinder) {
+				f.restarted[100] = true
+			},
+			want: events{"attach 100", "detach 100", "attach 100"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finder := newFakeFinder()
+			finder.running[100] = "Tradebot"
+			var got events
+			s := got.supervisor(finder)
+
+			s.Step()
+			tt.restart(finder)
+			s.Step()
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %q, want %q", got, tt.want)
+			}
+			if !finder.attached[0].closed {
+				t.Error("the old game's source wasn't closed")
+			}
+			if len(finder.attached) != 2 {
+				t.Errorf("attached %d times, want a fresh source after the restart", len(finder.attached))
+			}
+			if _, err := s.GetHoveredItem(); err != nil {
+				t.Errorf("GetHoveredItem() after the restart: %v", err)
+			}
+		})
+	}
+}
+
+func TestSupervisorRetriesFailedAttach(t *testing.T) {
+	finder := newFakeFinder()
+	finder.running[100] = "Tradebot"
+	finder.attachErrs[100] = fmt.Errorf("access denied")
+	var got events
+	s := got.supervisor(finder)
+
+	s.Step()
+	if len(s.Instances()) != 0 {
+		t.Fatalf("Instances() after a failed attach = %+v, want none", s.Instances())
+	}
+
+	delete(finder.attachErrs, 100)
+	s.Step()
+	if want := (events{"attach 100"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestSupervisorSelection(t *testing.T) {
+	finder := newFakeFinder()
+	finder.running[100] = "Tradebot"
+	finder.running[200] = "Mulehaven"
+	var got events
+	s := got.supervisor(finder)
+	s.Step()
+
+	if _, err := s.GetHoveredItem(); err == nil {
+		t.Error("GetHoveredItem() with two games and none selected succeeded")
+	}
+
+	item, err := s.GetHoveredItemFor("mulehaven")
+	if err != nil {
+		t.Fatalf("GetHoveredItemFor(): %v", err)
+	}
+	if item.Instance.PID != 200 {
+		t.Errorf("GetHoveredItemFor() read PID %d, want 200", item.Instance.PID)
+	}
+
+	if err := s.Select(300); err == nil {
+		t.Error("Select() of a PID that isn't attached succeeded")
+	}
+	if err := s.Select(100); err != nil {
+		t.Fatalf("Select(): %v", err)
+	}
+	item, err = s.GetHoveredItem()
+	if err != nil {
+		t.Fatalf("GetHoveredItem(): %v", err)
+	}
+	if item.Instance.PID != 100 {
+		t.Errorf("GetHoveredItem() read PID %d, want the selected 100", item.Instance.PID)
+	}
+}
+
+func TestSupervisorReadsNamesOncePerTick(t *testing.T) {
+	finder := newFakeFinder()
+	finder.running[100] = "Tradebot"
+	finder.running[200] = "Mulehaven"
+	var got events
+	s := got.supervisor(finder)
+	s.Step()
+
+	for i := 0; i < 3; i++ {
+		s.Instances()
+		if _, err := s.GetHoveredItemFor("Tradebot"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Select(200); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.ScanAllItems(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, source := range finder.attached {
+		if source.nameReads != 1 {
+			t.Errorf("%s's name was read %d times in one tick, want 1", source.character, source.nameReads)
+		}
+	}
+
+	// A character switch shows up on the next tick
+	finder.attached[0].character = "Switched"
+	finder.attached[1].character = "Switched"
+	s.Step()
+	for _, instance := range s.Instances() {
+		if instance.Character != "Switched" {
+			t.Errorf("instance %d character = %q after the next tick, want Switched", instance.PID, instance.Character)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package rolls
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// groupNames names properties that roll one value for several stats the
+// way the memory parser consolidates them
+var groupNames = map[string]string{
+	"dmg%":      "Enhanced Damage",
+	"res-all":   "to All Resistances",
+	"all-stats": "to All Attributes",
+}
+
+// StatRoll is how one variable stat rolled
+type StatRoll struct {
+	Property string  `json:"property"`
+	Min      int     `json:"min"`
+	Max      int     `json:"max"`
+	Actual   int     `json:"actual"`
+	Percent  float64 `json:"percent"` // 0 at the minimum roll, 100 at the maximum
+}
+
+// Perfection is how close an item's variable stats are to their maximum
+type Perfection struct {
+	Percent float64    `json:"percent"` // Average of the stat percentages
+	Stats   []StatRoll `json:"stats"`
+}
+
+// Score rates the variable stats of a unique, set item or runeword against
+// the ranges in UniqueItems.txt, SetItems.txt and Runes.txt. It returns nil
+// for items without known variable stats.
+func Score(item *models.Item) *Perfection {
+	if item == nil {
+		return nil
+	}
+
+	mods := itemMods(gamedata.Default(), item)
+	if len(mods) == 0 {
+		return nil
+	}
+
+	perfection := &Perfection{}
+	itemStats := ownStats(item)
+	for _, mod := range mods {
+		if roll, ok := scoreMod(gamedata.Default(), itemStats, mod); ok {
+			perfection.Stats = append(perfection.Stats, roll)
+		}
+	}
+
+	if len(perfection.Stats) == 0 {
+		return nil
+	}
+
+	sort.Slice(perfection.Stats, func(i, j int) bool {
+		return perfection.Stats[i].Property < perfection.Stats[j].Property
+	})
+
+	total := 0.0
+	for _, s := range perfection.Stats {
+		total += s.Percent
+	}
+	perfection.Percent = total / float64(len(perfection.Stats))
+
+	return perfection
+}
+
+// ownStats returns the item's stats without what its socketed runes, gems
+// and jewels add, so socket bonuses aren't scored as part of a roll
+func ownStats(item *models.Item) []models.Stat {
+	type key struct{ id, layer int }
+	added := make(map[key]int)
+	for _, socketed := range item.SocketedItems {
+		for _, s := range socketed.Stats {
+			added[key{s.ID, s.Layer}] += s.Value
+		}
+	}
+	if len(added) == 0 {
+		return item.Stats
+	}
+
+	own := make([]models.Stat, 0, len(item.Stats))
+	for _, s := range item.Stats {
+		s.Value -= added[key{s.ID, s.Layer}]
+		if s.Value != 0 {
+			own = append(own, s)
+		}
+	}
+	return own
+}
+
+// itemMods returns the properties the item's unique, set or runeword row adds
+func itemMods(data *gamedata.Data, item *models.Item) []gamedata.Mod {
+	switch {
+	case item.Runeword != "":
+		if rw, ok := data.Runeword(item.Runeword); ok {
+			return rw.Mods
+		}
+	case item.Quality == "Unique":
+		if u, ok := uniqueRow(data, item); ok {
+			return u.Mods
+		}
+	case item.Quality == "Set":
+		if s, ok := data.SetItem(item.Name); ok {
+			return s.Mods
+		}
+	}
+	return nil
+}
+
+// uniqueRow picks the item's row among the uniques with its name: the rows on
+// its base when it has one, then the row with the most properties the item
+// rolled stats for. That tells apart the eight Rainbow Facets by element and trigger.
+func uniqueRow(data *gamedata.Data, item *models.Item) (gamedata.UniqueItem, bool) {
+	rows := data.Uniques(item.Name)
+	var onBase []gamedata.UniqueItem
+	for _, u := range rows {
+		if u.Code == item.Code {
+			onBase = append(onBase, u)
+		}
+	}
+	if len(onBase) > 0 {
+		rows = onBase
+	}
+
+	var best gamedata.UniqueItem
+	bestCount := -1
+	for _, u := range rows {
+		count := 0
+		for _, mod := range u.Mods {
+			if hasModStat(data, item.Stats, mod) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = u, count
+		}
+	}
+	return best, bestCount >= 0
+}
+
+// hasModStat tells whether the item has a stat the property sets
+func hasModStat(data *gamedata.Data, itemStats []models.Stat, mod gamedata.Mod) bool {
+	layer, hasLayer := modLayer(mod)
+	for _, id := range data.PropertyStats(mod.Property) {
+		for _, s := range itemStats {
+			if s.ID == id && (!hasLayer || s.Layer == layer) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// modLayer returns the stat layer a skill property names in its parameter as
+// a number. Properties naming their skill by name have none.
+func modLayer(mod gamedata.Mod) (int, bool) {
+	if n, err := strconv.Atoi(mod.Param); err == nil {
+		return n, true
+	}
+	return -1, false
+}
+
+// scoreMod rates the stat a variable property rolled. Fixed properties,
+// charges, chance-to-cast and per-level stats aren't rolls and are skipped.
+func scoreMod(data *gamedata.Data, itemStats []models.Stat, mod gamedata.Mod) (StatRoll, bool) {
+	low, high := min(mod.Min, mod.Max), max(mod.Min, mod.Max)
+	if low == high {
+		return StatRoll{}, false
+	}
+
+	layer, hasLayer := modLayer(mod)
+
+	for _, id := range data.PropertyStats(mod.Property) {
+		entry, _ := stats.Default().Lookup(id)
+		switch entry.Encoding {
+		case stats.EncodingCharges, stats.EncodingSkillChance, stats.EncodingPerLevel:
+			return StatRoll{}, false
+		}
+
+		for _, s := range itemStats {
+			if s.ID != id || (hasLayer && s.Layer != layer) {
+				continue
+			}
+
+			name := groupNames[mod.Property]
+			if name == "" {
+				name = entry.Name
+			}
+			if name == "" {
+				name = mod.Property
+			}
+
+			percent := float64(s.Value-low) / float64(high-low) * 100
+			return StatRoll{
+				Property: name,
+				Min:      low,
+				Max:      high,
+				Actual:   s.Value,
+				Percent:  min(max(percent, 0), 100),
+			}, true
+		}
+	}
+	return StatRoll{}, false
+}
@@ -0,0 +1,184 @@
+package rolls
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// withTables installs game data built from the given tables for the duration of a test
+func withTables(t *testing.T, tables map[string]string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+
+	dir := t.TempDir()
+	for file, content := range tables {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := gamedata.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamedata.SetDefault(data)
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+}
+
+var testTables = map[string]string{
+	gamedata.FileItemStatCost: "Stat\t*ID\n" +
+		"strength\t0\n" +
+		"item_armor_percent\t16\n" +
+		"item_maxdamage_percent\t17\n" +
+		"item_mindamage_percent\t18\n" +
+		"fireresist\t39\n" +
+		"lightresist\t41\n" +
+		"coldresist\t43\n" +
+		"poisonresist\t45\n" +
+		"item_magicbonus\t80\n" +
+		"item_fastercastrate\t105\n" +
+		"item_allskills\t127\n",
+	gamedata.FileProperties: "code\tstat1\tstat2\tstat3\tstat4\n" +
+		"ac%\titem_armor_percent\t\t\t\n" +
+		"str\tstrength\t\t\t\n" +
+		"res-all\tfireresist\tlightresist\tcoldresist\tpoisonresist\n" +
+		"mag%\titem_magicbonus\t\t\t\n" +
+		"cast1\titem_fastercastrate\t\t\t\n" +
+		"allskills\titem_allskills\t\t\t\n" +
+		"dmg%\t\t\t\t\n",
+	gamedata.FileUniqueItems: "index\t*ID\tcode\tprop1\tmin1\tmax1\tprop2\tmin2\tmax2\tprop3\tmin3\tmax3\tprop4\tmin4\tmax4\n" +
+		"Harlequin Crest\t224\tuap\tallskills\t2\t2\tac%\t100\t150\tmag%\t50\t50\tstr\t2\t2\n" +
+		"The Gnasher\t0\thax\tdmg%\t60\t70\t\t\t\t\t\t\t\t\t\n",
+	gamedata.FileRunes: "Name\t*Rune Name\tcomplete\tT1Code1\tT1Min1\tT1Max1\tT1Code2\tT1Min2\tT1Max2\n" +
+		"Runeword65\tSpirit\t1\tcast1\t25\t35\tres-all\t30\t30\n",
+}
+
+func TestScore(t *testing.T) {
+	withTables(t, testTables)
+
+	tests := []struct {
+		name string
+		item *models.Item
+		want *Perfection
+	}{
+		{
+			name: "unique",
+			item: &models.Item{Name: "Harlequin Crest", Quality: "Unique", Stats: []models.Stat{
+				{ID: 127, Value: 2}, {ID: 16, Value: 141}, {ID: 80, Value: 50}, {ID: 0, Value: 2},
+			}},
+			want: &Perfection{Percent: 82, Stats: []StatRoll{
+				{Property: "Enhanced Defense", Min: 100, Max: 150, Actual: 141, Percent: 82},
+			}},
+		},
+		{
+			name: "runeword with a fixed all resistances",
+			item: &models.Item{Name: "Spirit", Runeword: "Spirit", Quality: "Normal", Stats: []models.Stat{
+				{ID: 105, Value: 30}, {ID: 39, Value: 30},
+			}},
+			want: &Perfection{Percent: 50, Stats: []StatRoll{
+				{Property: "Faster Cast Rate", Min: 25, Max: 35, Actual: 30, Percent: 50},
+			}},
+		},
+		{
+			name: "socketed rune left out of the roll",
+			item: &models.Item{Name: "Harlequin Crest", Quality: "Unique",
+				Stats:         []models.Stat{{ID: 127, Value: 2}, {ID: 16, Value: 171}, {ID: 80, Value: 50}, {ID: 0, Value: 2}},
+				SocketedItems: []models.SocketedItem{{Name: "Pul Rune", Type: "rune", Stats: []models.Stat{{ID: 16, Value: 30}}}},
+			},
+			want: &Perfection{Percent: 82, Stats: []StatRoll{
+				{Property: "Enhanced Defense", Min: 100, Max: 150, Actual: 141, Percent: 82},
+			}},
+		},
+		{
+			name: "enhanced damage through its function stats",
+			item: &models.Item{Name: "The Gnasher", Quality: "Unique", Stats: []models.Stat{{ID: 17, Value: 70}, {ID: 18, Value: 70}}},
+			want: &Perfection{Percent: 100, Stats: []StatRoll{
+				{Property: "Enhanced Damage", Min: 60, Max: 70, Actual: 70, Percent: 100},
+			}},
+		},
+		{
+			name: "item without a row",
+			item: &models.Item{Name: "Tyrael's Might", Quality: "Unique", Stats: []models.Stat{{ID: 16, Value: 141}}},
+		},
+		{
+			name: "imported item without stats",
+			item: &models.Item{Name: "Harlequin Crest", Quality: "Unique"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Score(tt.item)
+			if got != nil {
+				// Round for comparison
+				got.Percent = float64(int(got.Percent + 0.5))
+				for i := range got.Stats {
+					got.Stats[i].Percent = float64(int(got.Stats[i].Percent + 0.5))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Score() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreWithEmbeddedTables(t *testing.T) {
+	// No extracted tables, so the embedded ones are used
+	withTables(t, nil)
+
+	item := &models.Item{Name: "Spirit", Runeword: "Spirit", Quality: "Normal", Stats: []models.Stat{{ID: 105, Value: 30}}}
+	got := Score(item)
+	if got == nil || len(got.Stats) != 1 || got.Stats[0].Min != 25 || got.Stats[0].Max != 35 {
+		t.Errorf("Score() with the embedded tables = %+v, want the 25-35 faster cast rate", got)
+	}
+}
+
+func TestUniqueRow(t *testing.T) {
+	withTables(t, nil)
+
+	tests := []struct {
+		name string
+		item *models.Item
+		want int
+	}{
+		{
+			name: "rainbow facet by element and trigger",
+			item: &models.Item{Name: "Rainbow Facet", Code: "jew", Quality: "Unique", Stats: []models.Stat{
+				{ID: 331, Value: 4}, {ID: 335, Value: 4}, {ID: 199, Value: 100, Layer: 44 << 6}, // cold, skill on level-up
+			}},
+			want: 397,
+		},
+		{
+			name: "rainbow facet with another trigger",
+			item: &models.Item{Name: "Rainbow Facet", Code: "jew", Quality: "Unique", Stats: []models.Stat{
+				{ID: 331, Value: 4}, {ID: 335, Value: 4}, {ID: 197, Value: 100, Layer: 59 << 6}, // cold, skill on death
+			}},
+			want: 393,
+		},
+		{
+			name: "azurewrath by base",
+			item: &models.Item{Name: "Azurewrath", Code: "7cr", Quality: "Unique"},
+			want: 301,
+		},
+		{
+			name: "the first row without stats or base",
+			item: &models.Item{Name: "Rainbow Facet", Quality: "Unique"},
+			want: 392,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, ok := uniqueRow(gamedata.Default(), tt.item)
+			if !ok || u.ID != tt.want {
+				t.Errorf("uniqueRow() = %d, %v, want %d", u.ID, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package stats
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/config"
+)
+
+//go:embed catalog.json
+var embeddedCatalog []byte
+
+// OverrideFile is the catalog file in the data directory that replaces embedded entries
+const OverrideFile = "stat_catalog.json"
+
+// Encoding describes how a stat's value (and layer) should be read
+type Encoding string
+
+const (
+	EncodingValue           Encoding = "value"            // plain number
+	EncodingPercent         Encoding = "percent"          // number shown as a percentage
+	EncodingBool            Encoding = "bool"             // flag, the value is meaningless
+	EncodingFrames          Encoding = "frames"           // duration in frames (25 per second)
+	EncodingPerLevel        Encoding = "per_level"        // value >> PerLevelShift per character level
+	EncodingByTime          Encoding = "by_time"          // varies with time of day
+	EncodingClassSkills     Encoding = "class_skills"     // layer is the class
+	EncodingSkill           Encoding = "skill"            // layer is a skill ID
+	EncodingSkillTab        Encoding = "skill_tab"        // layer is class*8 + tab
+	EncodingElementalSkills Encoding = "elemental_skills" // layer is the element
+	EncodingSkillChance     Encoding = "skill_chance"     // layer is skill<<6 | level, value is chance
+	EncodingCharges         Encoding = "charges"          // layer is skill<<6 | level, value is max<<8 | charges
+	EncodingMonster         Encoding = "monster"          // layer is a monster ID
+	EncodingHidden          Encoding = "hidden"           // internal stat never shown on items
+)
+
+// Entry describes one stat from ItemStatCost
+type Entry struct {
+	ID       int      `json:"id"`
+	Key      string   `json:"key"`
+	Name     string   `json:"name"`
+	Encoding Encoding `json:"encoding"`
+	Layered  bool     `json:"layered"`
+	Traderie string   `json:"traderie,omitempty"`
+
+	// PerLevelShift is the op_param of per-level stats: the value grows by
+	// value / 2^PerLevelShift per character level
+	PerLevelShift int `json:"per_level_shift,omitempty"`
+}
+
+// Catalog is an indexed set of stat entries
+type Catalog struct {
+	entries map[int]Entry
+}
+
+var (
+	defaultCatalog *Catalog
+	defaultOnce    sync.Once
+)
+
+// Default returns the embedded catalog merged with the override file in the
+// data directory. It is loaded once; a broken override falls back to the embedded copy.
+func Default() *Catalog {
+	defaultOnce.Do(func() {
+		path := filepath.Join(config.DataDir(), OverrideFile)
+
+		catalog, err := Load(path)
+		if err != nil {
+			log.Printf("⚠️ Failed to load stat catalog override %s: %v", path, err)
+			catalog, _ = Load("")
+		}
+		defaultCatalog = catalog
+	})
+	return defaultCatalog
+}
+
+// Load parses the embedded catalog and applies entries from overridePath on
+// top of it. A missing override file is not an error.
+func Load(overridePath string) (*Catalog, error) {
+	catalog := &Catalog{entries: make(map[int]Entry)}
+	if err := catalog.merge(embeddedCatalog); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded stat catalog: %w", err)
+	}
+
+	if overridePath == "" {
+		return catalog, nil
+	}
+
+	raw, err := os.ReadFile(overridePath)
+	if os.IsNotExist(err) {
+		return catalog, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stat catalog: %w", err)
+	}
+
+	if err := catalog.merge(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse stat catalog: %w", err)
+	}
+
+	log.Printf("✓ Loaded stat catalog override from %s", overridePath)
+	return catalog, nil
+}
+
+// merge adds or replaces entries from a JSON array
+func (c *Catalog) merge(raw []byte) error {
+	var entries []Entry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Encoding == "" {
+			entry.Encoding = EncodingValue
+		}
+		c.entries[entry.ID] = entry
+	}
+	return nil
+}
+
+// Lookup returns the entry for a stat ID
+func (c *Catalog) Lookup(id int) (Entry, bool) {
+	entry, ok := c.entries[id]
+	return entry, ok
+}
+
+// Entries returns every entry ordered by stat ID
+func (c *Catalog) Entries() []Entry {
+	entries := make([]Entry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries
+}
+
+// TraderieNames maps each stat's display name to its Traderie property name
+func (c *Catalog) TraderieNames() map[string]string {
+	names := make(map[string]string)
+	for _, entry := range c.entries {
+		if entry.Traderie != "" {
+			names[entry.Name] = entry.Traderie
+		}
+	}
+	return names
+}
+
+// AtLevel returns a per-level stat's value for a character of the given level
+func (e Entry) AtLevel(value, level int) int {
+	return value * level >> e.PerLevelShift
+}
+
+// Hidden reports whether the stat never appears on an item tooltip
+func (e Entry) Hidden() bool {
+	return e.Encoding == EncodingHidden || e.Encoding == EncodingByTime
+}
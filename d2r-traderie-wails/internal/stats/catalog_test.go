@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hectorgimenez/d2go/pkg/data/stat"
+)
+
+func TestEmbeddedCatalog(t *testing.T) {
+	catalog, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// Every stat d2go knows has an entry
+	for id := range stat.StringStats {
+		if _, ok := catalog.Lookup(id); !ok {
+			t.Errorf("stat %d (%s) has no catalog entry", id, stat.StringStats[id])
+		}
+	}
+
+	for _, entry := range catalog.Entries() {
+		if entry.Encoding == EncodingPerLevel && entry.PerLevelShift == 0 {
+			t.Errorf("per-level stat %d (%s) has no per_level_shift", entry.ID, entry.Key)
+		}
+	}
+}
+
+func TestLoadMergesOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OverrideFile)
+	override := `[
+		{"id": 0, "key": "strength", "name": "Str", "encoding": "value"},
+		{"id": 9999, "key": "new_stat", "name": "New Stat"}
+	]`
+	if err := os.WriteFile(path, []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if entry, _ := catalog.Lookup(0); entry.Name != "Str" {
+		t.Errorf("Lookup(0).Name = %q, want the override's %q", entry.Name, "Str")
+	}
+	if entry, ok := catalog.Lookup(9999); !ok || entry.Encoding != EncodingValue {
+		t.Errorf("Lookup(9999) = %+v, %v, want the new entry with the value encoding", entry, ok)
+	}
+	if entry, _ := catalog.Lookup(7); entry.Name != "Max Life" {
+		t.Errorf("Lookup(7).Name = %q, want the embedded %q", entry.Name, "Max Life")
+	}
+}
+
+func TestLoadBadOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OverrideFile)
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load accepted a broken override")
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("Load with a missing override: %v", err)
+	}
+}
+
+func TestAtLevel(t *testing.T) {
+	tests := []struct {
+		shift, value, level, want int
+	}{
+		{3, 12, 99, 148}, // Life (Based on Character Level): 1.5 per level
+		{3, 8, 1, 1},
+		{1, 5, 99, 247}, // Attack Rating (Based on Character Level): 2.5 per level
+		{0, 2, 10, 20},
+	}
+
+	for _, tt := range tests {
+		entry := Entry{Encoding: EncodingPerLevel, PerLevelShift: tt.shift}
+		if got := entry.AtLevel(tt.value, tt.level); got != tt.want {
+			t.Errorf("AtLevel(%d, %d) with shift %d = %d, want %d", tt.value, tt.level, tt.shift, got, tt.want)
+		}
+	}
+}
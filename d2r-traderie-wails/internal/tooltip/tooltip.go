@@ -0,0 +1,421 @@
+package tooltip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/internal/memory"
+	"github.com/yourusername/d2r-traderie-wails/internal/stats"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// characterLevel is the level per-level stats are shown at, matching the parser
+const characterLevel = 99
+
+// Stat IDs the tooltip combines into a single line
+const (
+	statMaxDamagePercent = 17
+	statMinDamagePercent = 18
+	statPoisonMin        = 57
+	statPoisonMax        = 58
+	statPoisonLength     = 59
+	statColdLength       = 56
+)
+
+// damagePair is a min/max stat pair shown as one "Adds X-Y ..." line
+type damagePair struct {
+	min, max int
+	label    string
+	consumes []int // Stats that are folded into the line without showing on their own
+}
+
+// damagePairs lists the ranges the game joins into one line
+var damagePairs = []damagePair{
+	{21, 22, "Damage", []int{23, 24}},
+	{48, 49, "Fire Damage", nil},
+	{50, 51, "Lightning Damage", nil},
+	{52, 53, "Magic Damage", nil},
+	{54, 55, "Cold Damage", []int{statColdLength}},
+}
+
+// line is a stat line waiting to be sorted into tooltip order
+type line struct {
+	priority int
+	id       int
+	text     string
+}
+
+// statKey identifies a stat on an item
+type statKey struct {
+	id, layer int
+}
+
+// Render returns the item's tooltip as D2R shows it: name, base, defense or
+// damage, requirements, then stat lines by descending description priority
+func Render(item *models.Item) []string {
+	if item == nil {
+		return nil
+	}
+
+	lines := header(item)
+	if len(item.Stats) > 0 {
+		lines = append(lines, statLines(item.Stats)...)
+	} else {
+		lines = append(lines, propertyLines(item.Properties)...)
+	}
+
+	var flags []string
+	if item.IsEthereal {
+		flags = append(flags, "Ethereal (Cannot be Repaired)")
+	}
+	if item.Sockets > 0 {
+		flags = append(flags, fmt.Sprintf("Socketed (%d)", item.Sockets))
+	}
+	if len(flags) > 0 {
+		lines = append(lines, strings.Join(flags, ", "))
+	}
+
+	return lines
+}
+
+// Text returns the tooltip as newline-separated text, ready for the clipboard or a post
+func Text(item *models.Item) string {
+	return strings.Join(Render(item), "\n")
+}
+
+// header renders the lines above the stats
+func header(item *models.Item) []string {
+	lines := []string{item.Name}
+	if item.BaseName != "" && item.BaseName != item.Name {
+		lines = append(lines, item.BaseName)
+	}
+
+	if item.Runeword != "" {
+		var runes []string
+		for _, socketed := range item.SocketedItems {
+			name := strings.TrimSuffix(strings.TrimSuffix(socketed.Name, "Rune"), " ")
+			runes = append(runes, name)
+		}
+		lines = append(lines, "'"+strings.Join(runes, "")+"'")
+	}
+
+	if item.Defense > 0 {
+		lines = append(lines, fmt.Sprintf("Defense: %d", item.Defense))
+	}
+
+	if item.Damage != nil && item.Damage.Max > 0 {
+		hands := "One-Hand"
+		if base, ok := gamedata.Default().BaseByName(item.BaseName); ok && base.TwoHanded {
+			hands = "Two-Hand"
+		}
+		lines = append(lines, fmt.Sprintf("%s Damage: %d to %d", hands, item.Damage.Min, item.Damage.Max))
+	}
+
+	if req := item.Requirements; req != nil {
+		if req.Dexterity > 0 {
+			lines = append(lines, fmt.Sprintf("Required Dexterity: %d", req.Dexterity))
+		}
+		if req.Strength > 0 {
+			lines = append(lines, fmt.Sprintf("Required Strength: %d", req.Strength))
+		}
+		if req.Level > 0 {
+			lines = append(lines, fmt.Sprintf("Required Level: %d", req.Level))
+		}
+	}
+
+	return lines
+}
+
+// statLines describes raw stats with ItemStatCost's description columns
+func statLines(itemStats []models.Stat) []string {
+	data := gamedata.Default()
+
+	values := make(map[statKey]int)
+	for _, s := range itemStats {
+		values[statKey{s.ID, s.Layer}] += s.Value
+	}
+	consumed := make(map[statKey]bool)
+
+	has := func(id int) (int, bool) {
+		v, ok := values[statKey{id, 0}]
+		return v, ok && !consumed[statKey{id, 0}]
+	}
+	consume := func(ids ...int) {
+		for _, id := range ids {
+			consumed[statKey{id, 0}] = true
+		}
+	}
+	priority := func(id int) int {
+		sc, _ := data.Stat(id)
+		return sc.DescPriority
+	}
+
+	var out []line
+
+	// Equal min and max enhanced damage is shown as one line
+	if maxPct, ok := has(statMaxDamagePercent); ok {
+		if minPct, ok := has(statMinDamagePercent); ok && minPct == maxPct {
+			out = append(out, line{priority(statMaxDamagePercent), statMaxDamagePercent,
+				fmt.Sprintf("+%d%% Enhanced Weapon Damage", maxPct)})
+			consume(statMaxDamagePercent, statMinDamagePercent)
+		}
+	}
+
+	// Min/max damage pairs
+	for _, pair := range damagePairs {
+		minValue, hasMin := has(pair.min)
+		maxValue, hasMax := has(pair.max)
+		if !hasMin || !hasMax {
+			continue
+		}
+		out = append(out, line{priority(pair.max), pair.max, addsLine(minValue, maxValue, pair.label)})
+		consume(pair.min, pair.max)
+		consume(pair.consumes...)
+	}
+
+	// Poison damage is stored per frame and spread over its duration
+	if length, ok := has(statPoisonLength); ok && length > 0 {
+		minValue, _ := has(statPoisonMin)
+		maxValue, _ := has(statPoisonMax)
+		minDamage := minValue * length / 256
+		maxDamage := maxValue * length / 256
+		seconds := length / 25
+
+		text := fmt.Sprintf("Adds %d-%d Poison Damage Over %d Seconds", minDamage, maxDamage, seconds)
+		if minDamage == maxDamage {
+			text = fmt.Sprintf("+%d Poison Damage Over %d Seconds", maxDamage, seconds)
+		}
+		out = append(out, line{priority(statPoisonMax), statPoisonMax, text})
+		consume(statPoisonMin, statPoisonMax, statPoisonLength)
+	}
+
+	// Description groups (all resistances, all attributes) when every member is equal
+	groups := make(map[int]bool)
+	for key := range values {
+		if sc, ok := data.Stat(key.id); ok && sc.DescGroup != 0 {
+			groups[sc.DescGroup] = true
+		}
+	}
+	for group := range groups {
+		members := data.GroupStats(group)
+		if text, ok := groupLine(data, members, values, consumed); ok {
+			top := members[0]
+			for _, m := range members {
+				if m.DescPriority > top.DescPriority {
+					top = m
+				}
+			}
+			out = append(out, line{top.DescPriority, top.ID, text})
+			for _, m := range members {
+				consume(m.ID)
+			}
+		}
+	}
+
+	// Everything else, one line per stat
+	for _, s := range itemStats {
+		key := statKey{s.ID, s.Layer}
+		if consumed[key] {
+			continue
+		}
+		consumed[key] = true
+
+		if text := statLine(data, s.ID, values[key], s.Layer); text != "" {
+			out = append(out, line{priority(s.ID), s.ID, text})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].priority != out[j].priority {
+			return out[i].priority > out[j].priority
+		}
+		return out[i].id < out[j].id
+	})
+
+	lines := make([]string, 0, len(out))
+	for _, l := range out {
+		lines = append(lines, l.text)
+	}
+	return lines
+}
+
+// addsLine formats a damage range, e.g. "Adds 5-30 Fire Damage"
+func addsLine(minValue, maxValue int, label string) string {
+	if minValue == maxValue {
+		return fmt.Sprintf("+%d %s", maxValue, label)
+	}
+	return fmt.Sprintf("Adds %d-%d %s", minValue, maxValue, label)
+}
+
+// groupLine describes a description group when all of its stats are present and equal
+func groupLine(data *gamedata.Data, members []gamedata.StatCost, values map[statKey]int, consumed map[statKey]bool) (string, bool) {
+	if len(members) == 0 {
+		return "", false
+	}
+
+	first, ok := values[statKey{members[0].ID, 0}]
+	for _, m := range members {
+		v, present := values[statKey{m.ID, 0}]
+		if !ok || !present || consumed[statKey{m.ID, 0}] || v != first {
+			return "", false
+		}
+	}
+
+	sc := members[0]
+	str := sc.DescGroupStrPos
+	if first < 0 && sc.DescGroupStrNeg != "" {
+		str = sc.DescGroupStrNeg
+	}
+	if !data.HasString(str) {
+		// Without the strings the members get a line each
+		return "", false
+	}
+	return describe(sc.DescGroupFunc, sc.DescGroupVal, first, data.String(str), optionalString(data, sc.DescGroupStr2)), true
+}
+
+// statLine describes a single stat, or returns "" for stats with no tooltip line
+func statLine(data *gamedata.Data, id, value, layer int) string {
+	entry, known := stats.Default().Lookup(id)
+	if known {
+		switch entry.Encoding {
+		case stats.EncodingClassSkills, stats.EncodingSkill, stats.EncodingSkillTab,
+			stats.EncodingElementalSkills, stats.EncodingSkillChance, stats.EncodingCharges:
+			return memory.DescribeSkillStat(id, value, layer)
+		case stats.EncodingPerLevel:
+			value = entry.AtLevel(value, characterLevel)
+		}
+	}
+
+	sc, ok := data.Stat(id)
+	if ok && sc.DescFunc == 0 {
+		return ""
+	}
+
+	str := sc.DescStrPos
+	if value < 0 && sc.DescStrNeg != "" {
+		str = sc.DescStrNeg
+	}
+	if !ok || !data.HasString(str) {
+		// Not in the loaded ItemStatCost, or its string isn't loaded; fall back to the catalog name
+		if known && !entry.Hidden() {
+			return fmt.Sprintf("%s: %d", entry.Name, value)
+		}
+		return ""
+	}
+	return describe(sc.DescFunc, sc.DescVal, value, data.String(str), optionalString(data, sc.DescStr2))
+}
+
+// optionalString resolves a string key, or returns "" when it isn't loaded
+func optionalString(data *gamedata.Data, key string) string {
+	if !data.HasString(key) {
+		return ""
+	}
+	return data.String(key)
+}
+
+// describe applies an ItemStatCost descfunc. descval places the number:
+// 0 leaves it out, 1 puts it before the text and 2 after it.
+func describe(descFunc, descVal, value int, str1, str2 string) string {
+	var number string
+	switch descFunc {
+	case 1, 6, 12:
+		number = fmt.Sprintf("%+d", value)
+	case 2, 7:
+		number = fmt.Sprintf("%d%%", value)
+	case 4, 8:
+		number = fmt.Sprintf("%+d%%", value)
+	case 5, 10:
+		number = fmt.Sprintf("%d%%", value*100/128)
+	case 11:
+		// Self-repair: one durability every 100/value seconds
+		if value > 0 && strings.Contains(str1, "%d") {
+			return fmt.Sprintf(str1, 1, 100/value)
+		}
+		return str1
+	case 19:
+		if strings.Contains(str1, "%") {
+			return fmt.Sprintf(str1, value)
+		}
+		number = fmt.Sprintf("%+d", value)
+	case 20:
+		number = fmt.Sprintf("%d%%", -value)
+	case 21:
+		number = fmt.Sprintf("%d", -value)
+	default:
+		number = fmt.Sprintf("%d", value)
+	}
+
+	// On/off effects like "Hit Blinds Target" only show a number above 1
+	if descFunc == 12 && value <= 1 {
+		descVal = 0
+	}
+
+	var text string
+	switch descVal {
+	case 0:
+		text = str1
+	case 2:
+		text = str1 + " " + number
+	default:
+		text = number + " " + str1
+	}
+
+	if descFunc >= 6 && descFunc <= 10 && str2 != "" {
+		text += " " + str2
+	}
+	return strings.TrimSpace(text)
+}
+
+// propertyLines describes parsed properties when the raw stats are unknown,
+// e.g. for items imported from tooltip text
+func propertyLines(properties []models.Property) []string {
+	var lines []string
+	for _, prop := range properties {
+		switch v := prop.Value.(type) {
+		case nil:
+			lines = append(lines, prop.Name)
+		case bool:
+			if v {
+				lines = append(lines, prop.Name)
+			}
+		default:
+			value := fmt.Sprint(v)
+			if strings.Contains(prop.Name, value) {
+				lines = append(lines, prop.Name)
+			} else {
+				lines = append(lines, fmt.Sprintf("%s: %s", prop.Name, value))
+			}
+		}
+	}
+	return lines
+}
+
+// Check compares the parsed properties with the rendered tooltip and reports
+// stats the tooltip can't describe, so gaps in the game data show up early
+func Check(item *models.Item) []string {
+	if item == nil || len(item.Stats) == 0 {
+		return nil
+	}
+
+	data := gamedata.Default()
+	var problems []string
+	for _, s := range item.Stats {
+		entry, known := stats.Default().Lookup(s.ID)
+		if known && entry.Hidden() {
+			continue
+		}
+		if sc, ok := data.Stat(s.ID); ok && sc.DescFunc == 0 {
+			continue
+		}
+		_, inTable := data.Stat(s.ID)
+		switch {
+		case statLine(data, s.ID, s.Value, s.Layer) == "":
+			problems = append(problems, fmt.Sprintf("stat %d (layer %d, value %d) has no tooltip description", s.ID, s.Layer, s.Value))
+		case !inTable:
+			problems = append(problems, fmt.Sprintf("stat %d (%s) is missing from ItemStatCost", s.ID, entry.Key))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,190 @@
+package tooltip
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/d2r-traderie-wails/internal/gamedata"
+	"github.com/yourusername/d2r-traderie-wails/pkg/models"
+)
+
+// withTables installs game data built from the given tables for the duration of a test
+func withTables(t *testing.T, tables map[string]string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+
+	dir := t.TempDir()
+	for file, content := range tables {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := gamedata.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gamedata.SetDefault(data)
+	t.Cleanup(func() { gamedata.SetDefault(nil) })
+}
+
+var testTables = map[string]string{
+	gamedata.FileItemStatCost: "Stat\t*ID\tdescpriority\tdescfunc\tdescval\tdescstrpos\tdescstrneg\tdescstr2\tdgrp\tdgrpfunc\tdgrpval\tdgrpstrpos\tdgrpstrneg\tdgrpstr2\n" +
+		"strength\t0\t67\t1\t1\tModStr1a\tModStr1a\t\t1\t1\t1\tModitem2allattrib\tModitem2allattrib\t\n" +
+		"energy\t1\t61\t1\t1\tModStr1d\tModStr1d\t\t1\t1\t1\tModitem2allattrib\tModitem2allattrib\t\n" +
+		"dexterity\t2\t65\t1\t1\tModStr1b\tModStr1b\t\t1\t1\t1\tModitem2allattrib\tModitem2allattrib\t\n" +
+		"vitality\t3\t63\t1\t1\tModStr1c\tModStr1c\t\t1\t1\t1\tModitem2allattrib\tModitem2allattrib\t\n" +
+		"maxhp\t7\t59\t1\t1\tModStr1u\tModStr1u\t\t\t\t\t\t\t\n" +
+		"fireresist\t39\t36\t4\t2\tModStr1j\tModStr1j\t\t2\t19\t0\tstrModAllResistances\tstrModAllResistances\t\n" +
+		"lightresist\t41\t38\t4\t2\tModStr1l\tModStr1l\t\t2\t19\t0\tstrModAllResistances\tstrModAllResistances\t\n" +
+		"coldresist\t43\t40\t4\t2\tModStr1k\tModStr1k\t\t2\t19\t0\tstrModAllResistances\tstrModAllResistances\t\n" +
+		"poisonresist\t45\t34\t4\t2\tModStr1m\tModStr1m\t\t2\t19\t0\tstrModAllResistances\tstrModAllResistances\t\n" +
+		"coldmindam\t54\t96\t\t\t\t\t\t\t\t\t\t\t\n" +
+		"coldmaxdam\t55\t95\t\t\t\t\t\t\t\t\t\t\t\n" +
+		"coldlength\t56\t\t\t\t\t\t\t\t\t\t\t\t\n" +
+		"poisonmindam\t57\t92\t\t\t\t\t\t\t\t\t\t\t\n" +
+		"poisonmaxdam\t58\t91\t\t\t\t\t\t\t\t\t\t\t\n" +
+		"poisonlength\t59\t\t\t\t\t\t\t\t\t\t\t\t\n" +
+		"item_fasterattackrate\t93\t145\t4\t1\tModStr4m\tModStr4m\t\t\t\t\t\t\t\n" +
+		"item_allskills\t127\t158\t1\t1\tModStr3k\tModStr3k\t\t\t\t\t\t\t\n",
+	// ModStr1u (maxhp) is left out to show the catalog fallback
+	gamedata.FileItemModifiers: `[
+		{"id": 1, "Key": "ModStr1a", "enUS": "to Strength"},
+		{"id": 2, "Key": "ModStr1b", "enUS": "to Dexterity"},
+		{"id": 3, "Key": "ModStr1c", "enUS": "to Vitality"},
+		{"id": 4, "Key": "ModStr1d", "enUS": "to Energy"},
+		{"id": 5, "Key": "ModStr1j", "enUS": "Fire Resist"},
+		{"id": 6, "Key": "ModStr1k", "enUS": "Cold Resist"},
+		{"id": 7, "Key": "ModStr1l", "enUS": "Lightning Resist"},
+		{"id": 8, "Key": "ModStr1m", "enUS": "Poison Resist"},
+		{"id": 9, "Key": "ModStr4m", "enUS": "Increased Attack Speed"},
+		{"id": 10, "Key": "ModStr3k", "enUS": "to All Skills"},
+		{"id": 11, "Key": "Moditem2allattrib", "enUS": "to all Attributes"},
+		{"id": 12, "Key": "strModAllResistances", "enUS": "All Resistances +%d"}
+	]`,
+}
+
+func TestDescribe(t *testing.T) {
+	const repair = "Repairs %d durability in %d seconds"
+
+	tests := []struct {
+		descFunc, descVal, value int
+		str1                     string
+		want                     string
+	}{
+		{1, 0, 5, "Str", "Str"},
+		{1, 1, 5, "Str", "+5 Str"},
+		{1, 2, 5, "Str", "Str +5"},
+		{2, 0, 5, "Str", "Str"},
+		{2, 1, 5, "Str", "5% Str"},
+		{2, 2, 5, "Str", "Str 5%"},
+		{3, 0, 5, "Str", "Str"},
+		{3, 1, 5, "Str", "5 Str"},
+		{3, 2, 5, "Str", "Str 5"},
+		{4, 0, 5, "Str", "Str"},
+		{4, 1, 5, "Str", "+5% Str"},
+		{4, 2, 5, "Str", "Str +5%"},
+		{5, 0, 64, "Str", "Str"},
+		{5, 1, 64, "Str", "50% Str"},
+		{5, 2, 64, "Str", "Str 50%"},
+		{6, 0, 5, "Str", "Str Extra"},
+		{6, 1, 5, "Str", "+5 Str Extra"},
+		{6, 2, 5, "Str", "Str +5 Extra"},
+		{7, 0, 5, "Str", "Str Extra"},
+		{7, 1, 5, "Str", "5% Str Extra"},
+		{7, 2, 5, "Str", "Str 5% Extra"},
+		{8, 0, 5, "Str", "Str Extra"},
+		{8, 1, 5, "Str", "+5% Str Extra"},
+		{8, 2, 5, "Str", "Str +5% Extra"},
+		{9, 0, 5, "Str", "Str Extra"},
+		{9, 1, 5, "Str", "5 Str Extra"},
+		{9, 2, 5, "Str", "Str 5 Extra"},
+		{10, 0, 64, "Str", "Str Extra"},
+		{10, 1, 64, "Str", "50% Str Extra"},
+		{10, 2, 64, "Str", "Str 50% Extra"},
+		{11, 0, 4, repair, "Repairs 1 durability in 25 seconds"},
+		{11, 1, 4, repair, "Repairs 1 durability in 25 seconds"},
+		{11, 2, 4, repair, "Repairs 1 durability in 25 seconds"},
+		{12, 0, 5, "Str", "Str"},
+		{12, 1, 5, "Str", "+5 Str"},
+		{12, 2, 5, "Str", "Str +5"},
+		{12, 1, 1, "Str", "Str"},
+		{19, 0, 5, "Str %d", "Str 5"},
+		{19, 1, 5, "Str %d", "Str 5"},
+		{19, 2, 5, "Str %d", "Str 5"},
+		{19, 1, 5, "Str", "+5 Str"},
+		{20, 0, 5, "Str", "Str"},
+		{20, 1, 5, "Str", "-5% Str"},
+		{20, 2, 5, "Str", "Str -5%"},
+		{21, 0, 5, "Str", "Str"},
+		{21, 1, 5, "Str", "-5 Str"},
+		{21, 2, 5, "Str", "Str -5"},
+	}
+
+	for _, tt := range tests {
+		if got := describe(tt.descFunc, tt.descVal, tt.value, tt.str1, "Extra"); got != tt.want {
+			t.Errorf("describe(%d, %d, %d, %q) = %q, want %q", tt.descFunc, tt.descVal, tt.value, tt.str1, got, tt.want)
+		}
+	}
+}
+
+func TestStatLines(t *testing.T) {
+	withTables(t, testTables)
+
+	tests := []struct {
+		name  string
+		stats []models.Stat
+		want  []string
+	}{
+		{
+			name:  "all resistances",
+			stats: []models.Stat{{ID: 39, Value: 20}, {ID: 41, Value: 20}, {ID: 43, Value: 20}, {ID: 45, Value: 20}},
+			want:  []string{"All Resistances +20"},
+		},
+		{
+			name:  "resistances that differ",
+			stats: []models.Stat{{ID: 39, Value: 20}, {ID: 41, Value: 20}, {ID: 43, Value: 20}, {ID: 45, Value: 10}},
+			want:  []string{"Cold Resist +20%", "Lightning Resist +20%", "Fire Resist +20%", "Poison Resist +10%"},
+		},
+		{
+			name:  "all attributes",
+			stats: []models.Stat{{ID: 0, Value: 5}, {ID: 1, Value: 5}, {ID: 2, Value: 5}, {ID: 3, Value: 5}},
+			want:  []string{"+5 to all Attributes"},
+		},
+		{
+			name:  "poison over its length",
+			stats: []models.Stat{{ID: 57, Value: 512}, {ID: 58, Value: 1024}, {ID: 59, Value: 75}},
+			want:  []string{"Adds 150-300 Poison Damage Over 3 Seconds"},
+		},
+		{
+			name:  "poison with one value",
+			stats: []models.Stat{{ID: 57, Value: 512}, {ID: 58, Value: 512}, {ID: 59, Value: 75}},
+			want:  []string{"+150 Poison Damage Over 3 Seconds"},
+		},
+		{
+			name:  "cold length folded into the damage",
+			stats: []models.Stat{{ID: 54, Value: 10}, {ID: 55, Value: 20}, {ID: 56, Value: 50}},
+			want:  []string{"Adds 10-20 Cold Damage"},
+		},
+		{
+			name:  "by descending priority",
+			stats: []models.Stat{{ID: 0, Value: 10}, {ID: 93, Value: 20}, {ID: 127, Value: 2}},
+			want:  []string{"+2 to All Skills", "+20% Increased Attack Speed", "+10 to Strength"},
+		},
+		{
+			name:  "catalog name without the string",
+			stats: []models.Stat{{ID: 7, Value: 40}},
+			want:  []string{"Max Life: 40"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statLines(tt.stats); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("statLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
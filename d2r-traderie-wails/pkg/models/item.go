@@ -2,66 +2,156 @@ package models
 
 // Item represents a D2R item with all its properties
 type Item struct {
-	Name         string            `json:"name"`
-	Type         string            `json:"type"`
-	Quality      string            `json:"quality"`      // Normal, Magic, Rare, Unique, Set, Crafted, Rune, Gem
-	Properties   []Property        `json:"properties"`
-	Requirements *Requirements     `json:"requirements,omitempty"`
-	Sockets      int               `json:"sockets"`
-	Defense      int               `json:"defense,omitempty"`      // For armor
-	Damage       *DamageRange      `json:"damage,omitempty"`       // For weapons
-	ItemLevel    int               `json:"item_level,omitempty"`
-	IsIdentified bool              `json:"is_identified"`
-	IsEthereal   bool              `json:"is_ethereal"`
+	Name          string         `json:"name"`
+	BaseName      string         `json:"base_name,omitempty"` // Base item name, e.g. "Small Charm"
+	Code          string         `json:"code,omitempty"`      // Base item code, e.g. "cm1"
+	UnitID        int            `json:"unit_id,omitempty"`   // Game unit ID, or the item's saved ID for save files
+	Type          string         `json:"type"`
+	Quality       string         `json:"quality"` // Normal, Magic, Rare, Unique, Set, Crafted, Rune, Gem
+	Properties    []Property     `json:"properties"`
+	Stats         []Stat         `json:"stats,omitempty"` // Raw stats as the game stores them, including socketed items
+	Requirements  *Requirements  `json:"requirements,omitempty"`
+	Sockets       int            `json:"sockets"`
+	Defense       int            `json:"defense,omitempty"` // For armor
+	Damage        *DamageRange   `json:"damage,omitempty"`  // For weapons
+	ItemLevel     *int           `json:"item_level"`        // Nil when unknown: d2go doesn't read it, so memory scans never have it
+	IsIdentified  bool           `json:"is_identified"`
+	IsEthereal    bool           `json:"is_ethereal"`
+	Affixes       *Affixes       `json:"affixes,omitempty"` // Magic, rare and crafted items only
+	SocketedItems []SocketedItem `json:"socketed_items,omitempty"`
+	Runeword      string         `json:"runeword,omitempty"` // Runeword name, empty for other items
+	Location      *ItemLocation  `json:"location,omitempty"`
+	Instance      *GameInstance  `json:"instance,omitempty"`
+	Character     *Character     `json:"character,omitempty"` // Character holding the item, when known
+}
+
+// Affixes holds the affixes rolled on a magic, rare or crafted item
+type Affixes struct {
+	Prefixes   []Affix `json:"prefixes,omitempty"`    // Magic prefixes, rolled on magic and rare items
+	Suffixes   []Affix `json:"suffixes,omitempty"`    // Magic suffixes, rolled on magic and rare items
+	RarePrefix *Affix  `json:"rare_prefix,omitempty"` // First word of a rare or crafted name
+	RareSuffix *Affix  `json:"rare_suffix,omitempty"` // Second word of a rare or crafted name
+}
+
+// Affix is one affix ID from the game's affix tables, named when known
+type Affix struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// SocketedItem is a rune, gem or jewel inserted into an item
+type SocketedItem struct {
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`                 // rune, gem, jewel
+	Properties []Property `json:"properties,omitempty"` // Stats it adds to the item
+	Stats      []Stat     `json:"stats,omitempty"`      // The same stats as the game stores them
+}
+
+// GameInstance identifies the D2R process an item was scanned from
+type GameInstance struct {
+	PID       uint32 `json:"pid"`
+	Character string `json:"character"` // Character currently logged in, empty at the menus
+}
+
+// Character describes the character an item was scanned from
+type Character struct {
+	Name       string `json:"name"`
+	Class      string `json:"class,omitempty"` // Amazon, Sorceress, Necromancer, Paladin, Barbarian, Druid, Assassin
+	Level      int    `json:"level,omitempty"`
+	Hardcore   bool   `json:"hardcore"`
+	Ladder     bool   `json:"ladder"`
+	Expansion  bool   `json:"expansion"`
+	FlagsKnown bool   `json:"flags_known"` // Hardcore, ladder and expansion were read from the game or the character's save
+	Offline    bool   `json:"offline"`     // Single-player character, read from its save file or playing offline
+	LocalSave  bool   `json:"local_save"`  // A single-player save with the same name exists on this PC
+}
+
+// ItemLocation describes where an item was found in the game
+type ItemLocation struct {
+	Type string `json:"type"`           // stash, shared_stash, inventory, cube, equipped, mercenary, cursor, ground
+	Tab  int    `json:"tab,omitempty"`  // Shared stash tab number
+	Slot string `json:"slot,omitempty"` // Body slot for equipped and mercenary items
+	X    int    `json:"x,omitempty"`    // Column in a save file's grid or belt
+	Y    int    `json:"y,omitempty"`    // Row in a save file's grid
 }
 
 // Property represents a single item property/stat
 type Property struct {
-	Name  string      `json:"name"`
-	Value interface{} `json:"value"` // Can be int, string, or range
+	Name     string       `json:"name"`
+	Value    interface{}  `json:"value"`               // Can be int, string, or range
+	Traderie string       `json:"traderie,omitempty"`  // Traderie property, when the stat itself determines it
+	Skill    *SkillProc   `json:"skill,omitempty"`     // Set for chance-to-cast and charged skills
+	Damage   *DamageRange `json:"damage,omitempty"`    // Set for elemental damage ranges
+	PerLevel *PerLevel    `json:"per_level,omitempty"` // Set for stats based on character level
+}
+
+// Stat is one raw stat from the game's ItemStatCost table
+type Stat struct {
+	ID    int `json:"id"`
+	Layer int `json:"layer,omitempty"` // Skill, class or element, for stats that need one
+	Value int `json:"value"`
+}
+
+// PerLevel describes a stat that grows with character level
+type PerLevel struct {
+	Raw       int     `json:"raw"`         // Value stored on the item
+	PerLevel  float64 `json:"per_level"`   // Amount added per character level
+	AtLevel99 int     `json:"at_level_99"` // Value for a level 99 character
+}
+
+// SkillProc is a skill an item casts, either on a trigger or from charges
+type SkillProc struct {
+	SkillID    int    `json:"skill_id"`
+	Skill      string `json:"skill"`
+	Level      int    `json:"level"`
+	Chance     int    `json:"chance,omitempty"`      // Percent chance to cast
+	Trigger    string `json:"trigger,omitempty"`     // attack, striking, struck, kill, death, level-up
+	Charges    int    `json:"charges,omitempty"`     // Current charges
+	MaxCharges int    `json:"max_charges,omitempty"` // Maximum charges
 }
 
 // Requirements for equipping the item
 type Requirements struct {
-	Level      int `json:"level,omitempty"`
-	Strength   int `json:"strength,omitempty"`
-	Dexterity  int `json:"dexterity,omitempty"`
+	Level        int `json:"level,omitempty"`
+	Strength     int `json:"strength,omitempty"`
+	Dexterity    int `json:"dexterity,omitempty"`
 	Intelligence int `json:"intelligence,omitempty"`
 }
 
 // DamageRange for weapons
 type DamageRange struct {
-	Min int `json:"min"`
-	Max int `json:"max"`
-	Type string `json:"type"` // Physical, Fire, Cold, Lightning, Poison, Magic
+	Min      int     `json:"min"`
+	Max      int     `json:"max"`
+	Type     string  `json:"type"`               // Physical, Fire, Cold, Lightning, Poison, Magic
+	Duration float64 `json:"duration,omitempty"` // Seconds, for cold and poison damage
 }
 
 // TraderieItem represents the format expected by Traderie API (listings/create)
 type TraderieItem struct {
-	AcceptListingPrice  bool                   `json:"acceptListingPrice"`
-	Captcha             string                 `json:"captcha"`
-	CaptchaManaged      bool                   `json:"captchaManaged"`
-	CurrencyGroupPrices []CurrencyGroupPrice    `json:"currencyGroupPrices"`
-	EndTime             string                 `json:"endTime"`
-	Free                bool                   `json:"free"`
-	Item                string                 `json:"item"`     // Traderie Item ID
-	ItemMode            *string                `json:"itemMode"` // null
-	ItemType            string                 `json:"itemType"` // e.g., "sets", "uniques"
-	MakeOffer           bool                   `json:"makeOffer"`
-	NeedMaterials       bool                   `json:"needMaterials"`
-	OfferBells          bool                   `json:"offerBells"`
-	OfferNmt            bool                   `json:"offerNmt"`
-	OfferWishlist       bool                   `json:"offerWishlist"`
-	OfferWishlistId     string                 `json:"offerWishlistId"`
-	Selling             bool                   `json:"selling"`
-	StandingListing     bool                   `json:"standingListing"`
-	StockListing        bool                   `json:"stockListing"`
-	TouchTrading        bool                   `json:"touchTrading"`
-	Wishlist            string                 `json:"wishlist"`
-	Amount              string                 `json:"amount"` // e.g., "1"
-	Properties          []TraderieListingProp  `json:"properties"`
-	Offers              []string               `json:"offers,omitempty"` // For backward compatibility or specific use cases
-	Items               []TraderieListingItem  `json:"items,omitempty"`
+	AcceptListingPrice  bool                  `json:"acceptListingPrice"`
+	Captcha             string                `json:"captcha"`
+	CaptchaManaged      bool                  `json:"captchaManaged"`
+	CurrencyGroupPrices []CurrencyGroupPrice  `json:"currencyGroupPrices"`
+	EndTime             string                `json:"endTime"`
+	Free                bool                  `json:"free"`
+	Item                string                `json:"item"`     // Traderie Item ID
+	ItemMode            *string               `json:"itemMode"` // null
+	ItemType            string                `json:"itemType"` // e.g., "sets", "uniques"
+	MakeOffer           bool                  `json:"makeOffer"`
+	NeedMaterials       bool                  `json:"needMaterials"`
+	OfferBells          bool                  `json:"offerBells"`
+	OfferNmt            bool                  `json:"offerNmt"`
+	OfferWishlist       bool                  `json:"offerWishlist"`
+	OfferWishlistId     string                `json:"offerWishlistId"`
+	Selling             bool                  `json:"selling"`
+	StandingListing     bool                  `json:"standingListing"`
+	StockListing        bool                  `json:"stockListing"`
+	TouchTrading        bool                  `json:"touchTrading"`
+	Wishlist            string                `json:"wishlist"`
+	Amount              string                `json:"amount"` // e.g., "1"
+	Properties          []TraderieListingProp `json:"properties"`
+	Offers              []string              `json:"offers,omitempty"` // For backward compatibility or specific use cases
+	Items               []TraderieListingItem `json:"items,omitempty"`
 }
 
 // TraderieListingItem represents the item being listed in the "items" array
@@ -106,4 +196,3 @@ type Price struct {
 	Amount   float64 `json:"amount,omitempty"`
 	Currency string  `json:"currency,omitempty"` // "rune", "fg", etc.
 }
-